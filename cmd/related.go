@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newRelatedCmd(dataDirs *[]string) *cobra.Command {
+	var depth int
+
+	relatedCmd := &cobra.Command{
+		Use:   "related <document type> <_id> [relation...]",
+		Short: "Show the documents related to a document",
+		Long: `Show the documents related to a document.
+
+With relations, they are followed in order from the document, one hop each, e.g.
+  zs related Organizations 118 users submitted_tickets
+shows the tickets submitted by the users in organization 118.
+
+Without relations, every relation is followed up to --depth hops.`,
+		Example: "  zs related Tickets 436bf9b0-1147-4c0a-8439-6f79833bff5b submitter organization",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			location, err := loadLocation(viper.GetString("timezone"))
+			if err != nil {
+				return err
+			}
+
+			opts := append(storeOptions(location), implementations.WithWarnings(func(err error) {
+				log.Warn("Invalid value", "error", err)
+			}))
+			store, err := loadStore(*dataDirs, opts...)
+			if err != nil {
+				return err
+			}
+
+			doc, err := stores.FindContext(cmd.Context(), store, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			var related []models.Model
+			if path := args[2:]; len(path) > 0 {
+				related, err = store.RelatedContext(cmd.Context(), doc, path...)
+			} else {
+				related, err = store.WalkContext(cmd.Context(), doc, depth)
+			}
+			if err != nil {
+				return err
+			}
+
+			return printModels(cmd.OutOrStdout(), append([]models.Model{doc}, related...), location)
+		},
+	}
+
+	relatedCmd.Flags().IntVar(&depth, "depth", 1, "number of hops to follow when no relations are given")
+
+	return relatedCmd
+}
+
+func printModels(w io.Writer, found []models.Model, location *time.Location) error {
+	for _, m := range found {
+		header := m.DocumentType()
+		if source := models.SourceOf(m); source != "" {
+			header = fmt.Sprintf("%s [%s]", header, source)
+		}
+		if related, ok := m.(*models.RelatedModel); ok {
+			header = fmt.Sprintf("%s (%s)", header, related.Relation)
+		}
+		buf, err := models.StringOfIn(m, location)
+		if err != nil {
+			return fmt.Errorf("failed to string value: %w", err)
+		}
+		_, _ = fmt.Fprintf(w, "%s\n%s\n%s\n", header, strings.Repeat("-", len(header)), buf)
+	}
+	return nil
+}
@@ -2,11 +2,20 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/satrap-illustrations/zs/internal/stores/multi"
 	"github.com/satrap-illustrations/zs/internal/tui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -15,8 +24,10 @@ import (
 func Execute() error {
 	var (
 		cfgFile   string
-		dataDir   string
+		dataDirs  []string
 		debugFile string
+		timezone  string
+		strict    bool
 	)
 
 	cobra.OnInitialize(func() { initConfig(cfgFile) })
@@ -37,7 +48,17 @@ It searches Zendesk.`,
 				defer f.Close()
 			}
 
-			if _, err := tea.NewProgram(tui.InitialModel(dataDir)).Run(); err != nil {
+			location, err := loadLocation(viper.GetString("timezone"))
+			if err != nil {
+				return err
+			}
+
+			dirs, err := expandDataDirs(dataDirs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tea.NewProgram(tui.InitialModel(dirs, location, storeOptions(location)...)).Run(); err != nil {
 				return err
 			}
 
@@ -51,12 +72,12 @@ It searches Zendesk.`,
 		"",
 		"config file (default is $HOME/.config/zs/config.yaml)",
 	)
-	rootCmd.Flags().StringVarP(
-		&dataDir,
+	rootCmd.PersistentFlags().StringArrayVarP(
+		&dataDirs,
 		"data-dir",
 		"d",
-		"./data",
-		"data directory",
+		[]string{"./data"},
+		"data directory, or a glob of them, repeated to search several together",
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&debugFile,
@@ -65,7 +86,106 @@ It searches Zendesk.`,
 		"debug log file",
 	)
 
-	return rootCmd.ExecuteContext(context.Background())
+	rootCmd.PersistentFlags().StringVar(
+		&timezone,
+		"timezone",
+		"",
+		"IANA timezone to display and search timestamps in, so dates are those of the timezone, "+
+			"e.g. Australia/Melbourne (default is as written in the data)",
+	)
+	if err := viper.BindPFlag("timezone", rootCmd.PersistentFlags().Lookup("timezone")); err != nil {
+		return err
+	}
+
+	rootCmd.PersistentFlags().BoolVar(
+		&strict,
+		"strict",
+		false,
+		"fail to load data with invalid values in enumerated fields, instead of warning about them",
+	)
+	if err := viper.BindPFlag("strict", rootCmd.PersistentFlags().Lookup("strict")); err != nil {
+		return err
+	}
+
+	rootCmd.AddCommand(newRelatedCmd(&dataDirs), newSearchCmd(&dataDirs))
+
+	// interrupting a command cancels its searches, the tui handles ctrl+c itself.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// storeOptions returns the options to load the store with from the config, with timestamps in location.
+func storeOptions(location *time.Location) []implementations.Option {
+	opts := []implementations.Option{implementations.WithLocation(location)}
+	if viper.GetBool("strict") {
+		opts = append(opts, implementations.WithStrictValidation())
+	}
+	return opts
+}
+
+// expandDataDirs expands the globs among the data directories. A glob must match at least one directory.
+func expandDataDirs(patterns []string) ([]string, error) {
+	out := []string{}
+	add := func(dir string) {
+		if !slices.Contains(out, dir) {
+			out = append(out, dir)
+		}
+	}
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, `*?[\`) {
+			// a directory that does not exist fails to load, saying why.
+			add(pattern)
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data directory %q: %w", pattern, err)
+		}
+		found := false
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && info.IsDir() {
+				found = true
+				add(match)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no data directory matches %q", pattern)
+		}
+	}
+	return out, nil
+}
+
+// loadStore loads the store of the data directories, which are searched together if there are several.
+func loadStore(dataDirs []string, opts ...implementations.Option) (stores.Store, error) {
+	dirs, err := expandDataDirs(dataDirs)
+	if err != nil {
+		return nil, err
+	}
+	if len(dirs) == 1 {
+		return implementations.NewInvertedStore(dirs[0], opts...)
+	}
+	sources, err := multi.Load(dirs, func(dir string) (stores.Store, error) {
+		return implementations.NewInvertedStore(dir, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return multi.New(sources...)
+}
+
+// loadLocation loads the named timezone, "Local" for the system timezone.
+// The empty name returns nil, which leaves timestamps in the offset they were written with.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		//nolint:nilnil
+		return nil, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return location, nil
 }
 
 func initConfig(cfgFile string) {
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/log"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newSearchCmd(dataDirs *[]string) *cobra.Command {
+	var (
+		sortSpec   string
+		facetSpecs []string
+		page       stores.PageRequest
+	)
+
+	searchCmd := &cobra.Command{
+		Use:   "search <document type> <field> <query>",
+		Short: "Search for documents",
+		Long: `Search for the documents of a type with a field that matches the query,
+each followed by the documents related to it.
+
+The documents are in the order of their _id, or of --sort, a comma separated list of fields
+each followed by asc or desc, e.g. "created_at desc, priority asc".
+Enumerated fields are sorted in the order of their valid values, e.g. a ticket's priority from urgent to low.
+
+Each --facet aggregates a field over every document that matches, shown in a table before them:
+  priority            counts the documents with each priority, the same as --facet priority:terms
+  created_at:month    counts the documents created each month, or each day or year
+  user_count:stats    summarises the numbers in user_count`,
+		Example: `  zs search Tickets status pending --sort "priority asc, created_at desc" --limit 10
+  zs search Tickets status pending --facet priority --facet organization_id --facet created_at:month --limit 1`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			location, err := loadLocation(viper.GetString("timezone"))
+			if err != nil {
+				return err
+			}
+			if page.Sort, err = stores.ParseSort(sortSpec); err != nil {
+				return err
+			}
+			for _, spec := range facetSpecs {
+				facet, err := stores.ParseFacet(spec)
+				if err != nil {
+					return err
+				}
+				page.Facets = append(page.Facets, facet)
+			}
+
+			opts := append(storeOptions(location), implementations.WithWarnings(func(err error) {
+				log.Warn("Invalid value", "error", err)
+			}))
+			store, err := loadStore(*dataDirs, opts...)
+			if err != nil {
+				return err
+			}
+
+			found, err := store.SearchPage(cmd.Context(), args[0], args[1], args[2], page)
+			if err != nil {
+				return err
+			}
+
+			printFacets(cmd.OutOrStdout(), found.Facets)
+			for _, hit := range found.Hits {
+				if err := printModels(cmd.OutOrStdout(), hit.Models(), location); err != nil {
+					return err
+				}
+			}
+			if found.NextCursor != "" {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%d documents match, continue with --cursor %s\n",
+					found.Total, found.NextCursor)
+			}
+			return nil
+		},
+	}
+
+	searchCmd.Flags().StringVar(&sortSpec, "sort", "", `fields to sort by, e.g. "created_at desc, priority asc"`)
+	searchCmd.Flags().StringArrayVar(&facetSpecs, "facet", nil,
+		`field to aggregate, e.g. "priority", "created_at:month" or "user_count:stats", may be repeated`)
+	searchCmd.Flags().IntVar(&page.Limit, "limit", 0, "most documents to show, 0 for all of them")
+	searchCmd.Flags().IntVar(&page.Offset, "offset", 0, "number of documents to skip")
+	searchCmd.Flags().StringVar(&page.Cursor, "cursor", "", "continue after the documents shown by a previous search")
+
+	return searchCmd
+}
+
+// printFacets prints a table of the buckets or summary of each facet.
+func printFacets(w io.Writer, facets []stores.FacetResult) {
+	for _, facet := range facets {
+		header := facet.Facet.String()
+		_, _ = fmt.Fprintf(w, "%s\n%s\n", header, strings.Repeat("-", len(header)))
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if facet.Kind == stores.Stats {
+			summary := facet.Summary
+			_, _ = fmt.Fprintf(table, "count\tmin\tmax\tsum\tmean\n")
+			_, _ = fmt.Fprintf(table, "%d\t%g\t%g\t%g\t%.2f\n", summary.Count, summary.Min, summary.Max, summary.Sum, summary.Mean)
+		}
+		for _, bucket := range facet.Buckets {
+			key := bucket.Key
+			if key == "" {
+				key = "(empty)"
+			}
+			_, _ = fmt.Fprintf(table, "%s\t%d\n", key, bucket.Count)
+		}
+		_ = table.Flush()
+		_, _ = fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+)
+
+// Searcher finds the documents of the same type as like whose field matches query.
+// The documents related to them are not included.
+// It returns the error of ctx if ctx is done before the search is.
+type Searcher interface {
+	SearchLike(ctx context.Context, like models.Model, field, query string) ([]models.Model, error)
+}
+
+// Traverser follows relations between documents.
+// Its methods stop with the error of their context once the context is done.
+type Traverser struct {
+	searcher  Searcher
+	relations *models.Registry
+}
+
+func NewTraverser(searcher Searcher, relations *models.Registry) Traverser {
+	return Traverser{searcher: searcher, relations: relations}
+}
+
+// Neighbours returns the documents related to doc by each relation from it, labelled with the relation name.
+func (t Traverser) Neighbours(ctx context.Context, doc models.Model) ([]models.Model, error) {
+	doc = unwrap(doc)
+	out := []models.Model{}
+	for _, relation := range t.relations.From(doc) {
+		related, err := t.follow(ctx, doc, relation)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, models.Relate(relation.Name, related)...)
+	}
+	return out, nil
+}
+
+// Related follows the named relations from doc, one hop per name,
+// e.g. Related(organization, "users", "submitted_tickets") returns the tickets submitted by the users in the organization.
+// It returns each document reached by the last hop once, labelled with the path joined by dots.
+// Documents already reached by an earlier hop, including doc, are not visited again, so cycles end the traversal.
+func (t Traverser) Related(ctx context.Context, doc models.Model, path ...string) ([]models.Model, error) {
+	if len(path) == 0 {
+		return []models.Model{}, nil
+	}
+
+	doc = unwrap(doc)
+	visited := map[string]bool{key(doc): true}
+	frontier := []models.Model{doc}
+	for _, name := range path {
+		next := []models.Model{}
+		for _, m := range frontier {
+			relation, exists := t.relations.Lookup(m, name)
+			if !exists {
+				return nil, fmt.Errorf("%w: %s from %s", models.ErrRelationNotFound, name, m.DocumentType())
+			}
+			related, err := t.follow(ctx, m, relation)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range related {
+				if k := key(r); !visited[k] {
+					visited[k] = true
+					next = append(next, r)
+				}
+			}
+		}
+		frontier = next
+	}
+	return models.Relate(strings.Join(path, "."), frontier), nil
+}
+
+// Walk follows every relation from doc breadth first, up to depth hops.
+// It returns each document reached once, labelled with the path by which it was first reached, joined by dots.
+// doc itself is not returned.
+func (t Traverser) Walk(ctx context.Context, doc models.Model, depth int) ([]models.Model, error) {
+	type step struct {
+		model models.Model
+		path  string
+	}
+
+	doc = unwrap(doc)
+	visited := map[string]bool{key(doc): true}
+	frontier := []step{{model: doc}}
+	out := []models.Model{}
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		next := []step{}
+		for _, s := range frontier {
+			for _, relation := range t.relations.From(s.model) {
+				related, err := t.follow(ctx, s.model, relation)
+				if err != nil {
+					return nil, err
+				}
+				path := relation.Name
+				if s.path != "" {
+					path = s.path + "." + relation.Name
+				}
+				for _, r := range related {
+					if k := key(r); !visited[k] {
+						visited[k] = true
+						next = append(next, step{model: r, path: path})
+						out = append(out, &models.RelatedModel{Model: r, Relation: path})
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+	return out, nil
+}
+
+func (t Traverser) follow(ctx context.Context, doc models.Model, relation models.Relation) ([]models.Model, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	value, err := doc.ValueAt(relation.FromField)
+	if err != nil {
+		return nil, err
+	}
+	return t.searcher.SearchLike(ctx, relation.To, relation.ToField, fmt.Sprint(value))
+}
+
+// key identifies a document across document types.
+func key(m models.Model) string {
+	return m.DocumentType() + "/" + m.StringID()
+}
+
+func unwrap(m models.Model) models.Model {
+	if related, ok := m.(*models.RelatedModel); ok {
+		return unwrap(related.Model)
+	}
+	return m
+}
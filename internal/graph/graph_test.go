@@ -0,0 +1,129 @@
+package graph_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/satrap-illustrations/zs/internal/graph"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"gotest.tools/v3/assert"
+)
+
+const dataDir = "../../data"
+
+func TestRelated(t *testing.T) {
+	t.Parallel()
+
+	store, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+	traverser := graph.NewTraverser(store, models.Relations)
+
+	for _, tc := range []struct {
+		name          string
+		doc           models.Model
+		path          []string
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:        "organization_users",
+			doc:         &models.Organization{ID: 118},
+			path:        []string{"users"},
+			expectedIDs: []string{"49", "59"},
+		},
+		{
+			name: "organization_users_submitted_tickets",
+			doc:  &models.Organization{ID: 118},
+			path: []string{"users", "submitted_tickets"},
+			expectedIDs: []string{
+				"25cb699f-a5dd-45d8-9bc1-9c4b7d096946",
+				"59d803f6-a9cd-448c-a6bd-91ce9f044305",
+				"674a19a1-c330-45fb-8b61-b4d77ba87130",
+				"6e77bbf1-5fc7-4f41-aeb1-74f8730f974b",
+				"8ea53283-5b36-4328-9a78-f261ee90f44b",
+				"dae7a200-89b8-4a43-a17d-93c8f33a2aaa",
+			},
+		},
+		{
+			name:        "cycle_excludes_start",
+			doc:         &models.User{ID: 1, OrganizationID: 119},
+			path:        []string{"organization", "users"},
+			expectedIDs: []string{"48", "73", "75"},
+		},
+		{
+			name:        "cycle_back_to_start",
+			doc:         &models.User{ID: 1, OrganizationID: 119},
+			path:        []string{"organization", "users", "organization"},
+			expectedIDs: []string{},
+		},
+		{
+			name:        "empty_path",
+			doc:         &models.Organization{ID: 118},
+			expectedIDs: []string{},
+		},
+		{
+			name:          "unknown_relation",
+			doc:           &models.Organization{ID: 118},
+			path:          []string{"users", "fake"},
+			expectedError: models.ErrRelationNotFound,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			related, err := traverser.Related(context.Background(), tc.doc, tc.path...)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+
+			ids := []string{}
+			for _, r := range related {
+				relatedModel, ok := r.(*models.RelatedModel)
+				assert.Assert(t, ok)
+				assert.Equal(t, relatedModel.Relation, strings.Join(tc.path, "."))
+				ids = append(ids, r.StringID())
+			}
+			slices.Sort(ids)
+			assert.DeepEqual(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	store, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+	traverser := graph.NewTraverser(store, models.Relations)
+
+	organization := &models.Organization{ID: 118}
+
+	neighbours, err := traverser.Neighbours(context.Background(), organization)
+	assert.NilError(t, err)
+
+	oneHop, err := traverser.Walk(context.Background(), organization, 1)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, neighbours, oneHop)
+
+	twoHops, err := traverser.Walk(context.Background(), organization, 2)
+	assert.NilError(t, err)
+	assert.Assert(t, len(twoHops) > len(oneHop))
+
+	seen := map[string]bool{}
+	for _, m := range twoHops {
+		key := m.DocumentType() + "/" + m.StringID()
+		assert.Assert(t, !seen[key], "duplicate %s", key)
+		assert.Assert(t, key != "Organization/118", "start document returned")
+		seen[key] = true
+	}
+
+	none, err := traverser.Walk(context.Background(), organization, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(none))
+}
@@ -0,0 +1,358 @@
+// accessorgen generates reflection-free accessors for a model struct.
+//
+// For each exported field with a json tag, in declaration order, it generates
+// a field index constant, an entry in the field table returned by Fields,
+// and a case in the ValueAtIdx getter and SetValueAtIdx setter, as well as Clone.
+//
+// A field tagged computed:"name" is included under that name, even though it is not in the json.
+//
+// If the struct has a field of type Extras, it also generates json marshalling
+// that keeps the fields of the document that are not declared in the struct in it.
+//
+// It is intended to be run with go:generate from the file declaring the type:
+//
+//	//go:generate go run ./accessorgen -type Organization
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var ErrTypeNotFound = errors.New("type not found")
+
+type field struct {
+	// GoName is the name of the struct field.
+	GoName string
+	// Name is the name of the field in the json document.
+	Name string
+	// Type is the type of the struct field as written in the source.
+	Type string
+}
+
+type model struct {
+	Command  string
+	Package  string
+	Type     string
+	Receiver string
+	Table    string
+	// Imports are grouped like goimports: the standard library, then the rest.
+	StdImports []string
+	Imports    []string
+	Fields     []field
+	// Extras is the name of the field of type Extras, if any.
+	Extras string
+}
+
+func main() {
+	var (
+		typeName string
+		output   string
+	)
+	flag.StringVar(&typeName, "type", "", "name of the struct type to generate accessors for")
+	flag.StringVar(&output, "output", "", "output file name (default is <type>_accessors.go)")
+	flag.Parse()
+
+	if typeName == "" {
+		log.Fatal("accessorgen: -type is required")
+	}
+	if output == "" {
+		output = toSnakeCase(typeName) + "_accessors.go"
+	}
+
+	input := os.Getenv("GOFILE")
+	if input == "" {
+		log.Fatal("accessorgen: GOFILE is not set, run with go generate")
+	}
+
+	src, err := generate(input, typeName)
+	if err != nil {
+		log.Fatalf("accessorgen: %v", err)
+	}
+
+	if err := os.WriteFile(output, src, 0o600); err != nil {
+		log.Fatalf("accessorgen: %v", err)
+	}
+}
+
+func generate(input, typeName string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, input, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := parseModel(fset, file, typeName)
+	if err != nil {
+		return nil, err
+	}
+	m.Command = "accessorgen -type " + typeName
+
+	var buf bytes.Buffer
+	if err := accessorsTemplate.Execute(&buf, m); err != nil {
+		return nil, err
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w\n%s", err, buf.String())
+	}
+	return out, nil
+}
+
+func parseModel(fset *token.FileSet, file *ast.File, typeName string) (*model, error) {
+	var st *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == typeName {
+			st, _ = ts.Type.(*ast.StructType)
+			return false
+		}
+		return st == nil
+	})
+	if st == nil {
+		return nil, fmt.Errorf("%w: struct %s in %s", ErrTypeNotFound, typeName, fset.File(file.Pos()).Name())
+	}
+
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = spec.Path.Value
+	}
+
+	m := &model{
+		Package:  file.Name.Name,
+		Type:     typeName,
+		Receiver: strings.ToLower(typeName[:1]),
+		Table:    strings.ToLower(typeName[:1]) + typeName[1:] + "Fields",
+	}
+	usedImports := map[string]bool{
+		`"fmt"`: true,
+		`"github.com/elliotchance/orderedmap/v2"`: true,
+	}
+	for _, f := range st.Fields.List {
+		var typeBuf bytes.Buffer
+		if err := printer.Fprint(&typeBuf, fset, f.Type); err != nil {
+			return nil, err
+		}
+		ast.Inspect(f.Type, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if pkg, ok := sel.X.(*ast.Ident); ok {
+					if path, ok := imports[pkg.Name]; ok {
+						usedImports[path] = true
+					}
+				}
+			}
+			return true
+		})
+
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			if typeBuf.String() == "Extras" {
+				m.Extras = ident.Name
+				continue
+			}
+			name := ident.Name
+			if f.Tag != nil {
+				tag, err := strconv.Unquote(f.Tag.Value)
+				if err != nil {
+					return nil, err
+				}
+				jsonName, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+				computedName := reflect.StructTag(tag).Get("computed")
+				switch {
+				case computedName != "":
+					name = computedName
+				case jsonName == "-":
+					continue
+				case jsonName == "":
+				default:
+					name = jsonName
+				}
+			}
+			m.Fields = append(m.Fields, field{GoName: ident.Name, Name: name, Type: typeBuf.String()})
+		}
+	}
+
+	if m.Extras != "" {
+		usedImports[`"encoding/json"`] = true
+	}
+	for path := range usedImports {
+		// standard library import paths have no dot in the first element
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			m.Imports = append(m.Imports, path)
+		} else {
+			m.StdImports = append(m.StdImports, path)
+		}
+	}
+	slices.Sort(m.StdImports)
+	slices.Sort(m.Imports)
+
+	return m, nil
+}
+
+func toSnakeCase(s string) string {
+	var out strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				out.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+var accessorsTemplate = template.Must(template.New("accessors").Parse(`// Code generated by {{.Command}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .StdImports}}
+	{{.}}
+{{- end}}
+{{range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// Indices of the fields of {{.Type}}, as used by ValueAtIdx and SetValueAtIdx.
+const (
+{{- range $i, $f := .Fields}}
+	{{$.Type}}Field{{$f.GoName}}{{if eq $i 0}} = iota{{end}}
+{{- end}}
+)
+
+var {{.Table}} = newFieldTable(
+{{- range .Fields}}
+	{{printf "%q" .Name}},
+{{- end}}
+)
+
+// Fields returns the fields in the {{.Type}} mapped to an index.
+// The map is shared and must not be modified.
+func (*{{.Type}}) Fields() *orderedmap.OrderedMap[string, int] {
+	return {{.Table}}
+}
+
+// ValueAtIdx returns the value of the field in the {{.Type}} at i, or nil if there is no such field.
+func ({{.Receiver}} *{{.Type}}) ValueAtIdx(i int) any {
+	if {{.Receiver}} == nil {
+		{{.Receiver}} = &{{.Type}}{}
+	}
+	switch i {
+{{- range .Fields}}
+	case {{$.Type}}Field{{.GoName}}:
+		return {{$.Receiver}}.{{.GoName}}
+{{- end}}
+	default:
+		return nil
+	}
+}
+
+// ValueAt returns the value of the field in the {{.Type}}.
+// It returns ErrFieldNotFound if the field does not exist.
+func ({{.Receiver}} *{{.Type}}) ValueAt(field string) (any, error) {
+	i, exists := {{.Table}}.Get(field)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrFieldNotFound, field)
+	}
+	return {{.Receiver}}.ValueAtIdx(i), nil
+}
+
+// SetValueAtIdx sets the value of the field in the {{.Type}} at i.
+// It returns ErrFieldNotFound if there is no such field and ErrFieldType if value has the wrong type.
+func ({{.Receiver}} *{{.Type}}) SetValueAtIdx(i int, value any) error {
+	switch i {
+{{- range .Fields}}
+	case {{$.Type}}Field{{.GoName}}:
+		v, ok := value.({{.Type}})
+		if !ok {
+			return fmt.Errorf("%w: %s is %T, got %T", ErrFieldType, {{printf "%q" .Name}}, v, value)
+		}
+		{{$.Receiver}}.{{.GoName}} = v
+{{- end}}
+	default:
+		return fmt.Errorf("%w: %d", ErrFieldNotFound, i)
+	}
+	return nil
+}
+
+// SetValueAt sets the value of the field in the {{.Type}}.
+// It returns ErrFieldNotFound if the field does not exist and ErrFieldType if value has the wrong type.
+func ({{.Receiver}} *{{.Type}}) SetValueAt(field string, value any) error {
+	i, exists := {{.Table}}.Get(field)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrFieldNotFound, field)
+	}
+	return {{.Receiver}}.SetValueAtIdx(i, value)
+}
+
+// Clone returns a shallow copy of the {{.Type}}, which shares its slices and maps.
+func ({{.Receiver}} *{{.Type}}) Clone() Model {
+	if {{.Receiver}} == nil {
+		return &{{.Type}}{}
+	}
+	clone := *{{.Receiver}}
+	return &clone
+}
+{{- if .Extras}}
+
+// ExtraFields returns the fields of the document that are not declared in {{.Type}}.
+func ({{.Receiver}} *{{.Type}}) ExtraFields() Extras {
+	if {{.Receiver}} == nil {
+		return nil
+	}
+	return {{.Receiver}}.{{.Extras}}
+}
+
+// UnmarshalJSON decodes the declared fields, and keeps the rest in {{.Extras}}.
+func ({{.Receiver}} *{{.Type}}) UnmarshalJSON(data []byte) error {
+	type declared {{.Type}}
+	if err := json.Unmarshal(data, (*declared)({{.Receiver}})); err != nil {
+		return err
+	}
+	extras, err := unmarshalExtras(data, {{.Receiver}})
+	if err != nil {
+		return err
+	}
+	{{.Receiver}}.{{.Extras}} = extras
+	return nil
+}
+
+// MarshalJSON encodes the declared fields, followed by those in {{.Extras}}.
+func ({{.Receiver}} {{.Type}}) MarshalJSON() ([]byte, error) {
+	type declared {{.Type}}
+	data, err := json.Marshal(declared({{.Receiver}}))
+	if err != nil {
+		return nil, err
+	}
+	return marshalWithExtras(data, {{.Receiver}}.{{.Extras}})
+}
+{{- end}}
+`))
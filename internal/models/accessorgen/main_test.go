@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGeneratedAccessorsAreUpToDate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		typeName, input, output string
+	}{
+		{typeName: "Organization", input: "organization.go", output: "organization_accessors.go"},
+		{typeName: "Ticket", input: "ticket.go", output: "ticket_accessors.go"},
+		{typeName: "User", input: "user.go", output: "user_accessors.go"},
+		{typeName: "Group", input: "group.go", output: "group_accessors.go"},
+		{typeName: "Comment", input: "comment.go", output: "comment_accessors.go"},
+		{typeName: "Audit", input: "audit.go", output: "audit_accessors.go"},
+	} {
+		tc := tc
+		t.Run(tc.typeName, func(t *testing.T) {
+			t.Parallel()
+
+			generated, err := generate(filepath.Join("..", tc.input), tc.typeName)
+			assert.NilError(t, err)
+
+			existing, err := os.ReadFile(filepath.Join("..", tc.output))
+			assert.NilError(t, err)
+
+			assert.Equal(t, string(existing), string(generated), "run go generate ./internal/models")
+		})
+	}
+}
+
+func TestTypeNotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := generate(filepath.Join("..", "model.go"), "Organization")
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+}
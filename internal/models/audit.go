@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run ./accessorgen -type Audit
+
+// Audit is a change made to a ticket.
+// The events of the change are kept in Extras, so they can be searched by dotted path, e.g. events.field_name.
+type Audit struct {
+	ID        int       `json:"_id"`
+	TicketID  uuid.UUID `json:"ticket_id"`
+	AuthorID  int       `json:"author_id"`
+	CreatedAt Timestamp `json:"created_at"`
+	Via       TicketVia `json:"via"`
+
+	// Extras holds the fields in the data that are not declared above.
+	Extras Extras `json:"-"`
+}
+
+func (*Audit) DocumentType() string {
+	return "Audit"
+}
+
+func (a *Audit) StringID() string {
+	if a == nil {
+		a = &Audit{}
+	}
+	return strconv.Itoa(a.ID)
+}
+
+func (a *Audit) String() (string, error) {
+	if a == nil {
+		a = &Audit{}
+	}
+	return StringOf(a)
+}
+
+// AuditSliceToModelsSlice converts a slice of Audit to a slice of Model.
+func AuditSliceToModelsSlice(in []Audit) []Model {
+	out := make([]Model, 0, len(in))
+	for _, v := range in {
+		v := v
+		out = append(out, &v)
+	}
+	return out
+}
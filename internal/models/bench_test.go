@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+)
+
+// reflectedValueAt is how ValueAt was implemented before the accessors were generated.
+// It is kept here as a baseline for the benchmarks.
+func reflectedValueAt(m any, field string) any {
+	value := reflect.Indirect(reflect.ValueOf(m))
+	ty := value.Type()
+	for i := 0; i < ty.NumField(); i++ {
+		name, _, _ := strings.Cut(ty.Field(i).Tag.Get("json"), ",")
+		if name == field {
+			return value.Field(i).Interface()
+		}
+	}
+	return nil
+}
+
+func BenchmarkValueAt(b *testing.B) {
+	ticket := &models.Ticket{ID: dummyUUID, Via: "web"}
+
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ticket.ValueAt("via"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = reflectedValueAt(ticket, "via")
+		}
+	})
+}
+
+func BenchmarkFields(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = models.FieldSlice(new(models.User))
+	}
+}
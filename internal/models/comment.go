@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run ./accessorgen -type Comment
+
+// Comment is a comment on a ticket.
+type Comment struct {
+	ID        int       `json:"_id"`
+	TicketID  uuid.UUID `json:"ticket_id"`
+	AuthorID  int       `json:"author_id"`
+	Body      string    `json:"body"`
+	Public    bool      `json:"public"`
+	CreatedAt Timestamp `json:"created_at"`
+	Via       TicketVia `json:"via"`
+
+	// Extras holds the fields in the data that are not declared above.
+	Extras Extras `json:"-"`
+}
+
+func (*Comment) DocumentType() string {
+	return "Comment"
+}
+
+func (c *Comment) StringID() string {
+	if c == nil {
+		c = &Comment{}
+	}
+	return strconv.Itoa(c.ID)
+}
+
+func (c *Comment) String() (string, error) {
+	if c == nil {
+		c = &Comment{}
+	}
+	return StringOf(c)
+}
+
+// CommentSliceToModelsSlice converts a slice of Comment to a slice of Model.
+func CommentSliceToModelsSlice(in []Comment) []Model {
+	out := make([]Model, 0, len(in))
+	for _, v := range in {
+		v := v
+		out = append(out, &v)
+	}
+	return out
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// FieldValue returns the value of the field in m, declared or computed, or of the extra field at the dotted path.
+// An extra field with one value returns that value, with several returns them all as []any, and with none returns nil.
+func FieldValue(m Model, field string) any {
+	if value, err := m.ValueAt(field); err == nil {
+		return value
+	}
+	values := m.ExtraFields().Flatten()[field]
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return values
+	}
+}
+
+// CompareValues orders two field values, returning -1, 0 or +1 like cmp.Compare.
+// Values of the same type are compared by their type: numbers numerically, false before true,
+// UUIDs by their bytes, timestamps in time, enums in the order of their Values, and slices element by element.
+// Missing values, nil and the zero Timestamp, come first, and values of different types are ordered by their type.
+func CompareValues(a, b any) int {
+	rank := valueRank(a)
+	if c := cmp.Compare(rank, valueRank(b)); c != 0 || rank == 0 {
+		return c
+	}
+
+	switch x := a.(type) {
+	case string:
+		return cmp.Compare(x, b.(string))
+
+	case []string:
+		return slices.Compare(x, b.([]string))
+
+	case bool:
+		switch y := b.(bool); {
+		case x == y:
+			return 0
+		case y:
+			return -1
+		default:
+			return +1
+		}
+
+	case int:
+		if y, ok := b.(int); ok {
+			return cmp.Compare(x, y)
+		}
+		return cmp.Compare(float64(x), b.(float64))
+
+	// extra fields are decoded from json without a declared type
+	case float64:
+		if y, ok := b.(int); ok {
+			return cmp.Compare(x, float64(y))
+		}
+		return cmp.Compare(x, b.(float64))
+
+	case uuid.UUID:
+		y := b.(uuid.UUID)
+		return bytes.Compare(x[:], y[:])
+
+	case Timestamp:
+		return x.Compare(b.(Timestamp).Time)
+
+	case Enum:
+		return compareEnums(x, b.(Enum))
+
+	case []any:
+		return slices.CompareFunc(x, b.([]any), CompareValues)
+
+	// other types don't appear in the data, compare them by how they print
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+// compareEnums orders enums by the position of their value in Values, followed by invalid values in order.
+func compareEnums(a, b Enum) int {
+	position := func(e Enum) int {
+		if i := slices.Index(e.Values(), e.String()); i >= 0 {
+			return i
+		}
+		return len(e.Values())
+	}
+	if c := cmp.Compare(position(a), position(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.String(), b.String())
+}
+
+// valueRank orders the types of values, so that values of different types compare consistently.
+// Values of the same rank are of the same type, apart from ints and float64s, which are both numbers.
+func valueRank(v any) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case Timestamp:
+		if v.IsZero() {
+			return 0
+		}
+		return 5
+	case bool:
+		return 1
+	case int, float64:
+		return 2
+	case uuid.UUID:
+		return 3
+	case Enum:
+		return 4
+	case string:
+		return 6
+	case []string:
+		return 7
+	case []any:
+		return 8
+	default:
+		return 9
+	}
+}
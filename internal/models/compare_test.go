@@ -0,0 +1,65 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestCompareValues(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		a, b any
+		want int
+	}{
+		{name: "int", a: 2, b: 10, want: -1},
+		{name: "int_equal", a: 7, b: 7, want: 0},
+		{name: "int_float", a: 2, b: 1.5, want: +1},
+		{name: "string", a: "b", b: "a", want: +1},
+		{name: "bool", a: false, b: true, want: -1},
+		{name: "uuid", a: uuid.MustParse("01000000-0000-0000-0000-000000000000"), b: uuid.MustParse("00ff0000-0000-0000-0000-000000000000"), want: +1},
+		{
+			name: "timestamp_offsets",
+			a:    models.MustParseTimestamp("2016-04-28T11:19:34 -10:00"),
+			b:    models.MustParseTimestamp("2016-04-28T20:19:34 +00:00"),
+			want: +1,
+		},
+		{name: "timestamp_missing", a: models.Timestamp{}, b: models.MustParseTimestamp("2016-04-28T11:19:34 -10:00"), want: -1},
+		{name: "enum_values_order", a: models.TicketPriorityLow, b: models.TicketPriorityUrgent, want: +1},
+		{name: "enum_invalid_last", a: models.TicketPriority("whenever"), b: models.TicketPriorityLow, want: +1},
+		{name: "tags", a: []string{"a", "b"}, b: []string{"a", "c"}, want: -1},
+		{name: "extras", a: []any{"x", 1.0}, b: []any{"x"}, want: +1},
+		{name: "nil_first", a: nil, b: false, want: -1},
+		{name: "nil_missing_timestamp", a: nil, b: models.Timestamp{}, want: 0},
+		{name: "mixed_types", a: "1", b: 1.0, want: +1},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, models.CompareValues(tc.a, tc.b))
+			assert.Equal(t, -tc.want, models.CompareValues(tc.b, tc.a))
+		})
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	t.Parallel()
+
+	ticket := &models.Ticket{
+		Priority: models.TicketPriorityHigh,
+		Overdue:  true,
+		Extras: models.Extras{
+			"custom_fields": map[string]any{"region": "apac"},
+			"fields":        []any{map[string]any{"value": "gold"}, map[string]any{"value": "silver"}},
+		},
+	}
+	assert.Equal(t, models.TicketPriorityHigh, models.FieldValue(ticket, "priority"))
+	assert.Equal(t, true, models.FieldValue(ticket, "overdue"))
+	assert.Equal(t, "apac", models.FieldValue(ticket, "custom_fields.region"))
+	assert.DeepEqual(t, []any{"gold", "silver"}, models.FieldValue(ticket, "fields.value"))
+	assert.Equal(t, nil, models.FieldValue(ticket, "missing"))
+}
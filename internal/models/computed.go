@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+)
+
+// ComputeContext is what computed fields are derived from, besides the document itself.
+type ComputeContext struct {
+	// Now is the time the data is loaded.
+	Now time.Time
+
+	// Related returns the documents related to doc by the named relation in Relations.
+	Related func(doc Model, relation string) ([]Model, error)
+}
+
+// ComputedField is a field whose value is derived from a document and its relations when the data is loaded.
+// The field is declared in the Model with a computed tag, so it is listed, searched and shown like the stored fields.
+type ComputedField struct {
+	Model Model
+	Field string
+	// Compute returns the value of the field in doc, with the type of the field.
+	Compute func(doc Model, c ComputeContext) (any, error)
+}
+
+// ComputedFields are the computed fields of all the document types.
+var ComputedFields = []ComputedField{
+	{Model: &Ticket{}, Field: "overdue", Compute: ticketOverdue},
+	{Model: &User{}, Field: "submitted_ticket_count", Compute: countRelated("submitted_tickets")},
+	{Model: &User{}, Field: "assigned_ticket_count", Compute: countRelated("assigned_tickets")},
+	{Model: &Organization{}, Field: "user_count", Compute: countRelated("users")},
+	{Model: &Organization{}, Field: "ticket_count", Compute: countRelated("tickets")},
+}
+
+// Compute sets the computed fields of doc.
+func Compute(doc Model, c ComputeContext) error {
+	for _, computed := range ComputedFields {
+		if computed.Model.DocumentType() != doc.DocumentType() {
+			continue
+		}
+		value, err := computed.Compute(doc, c)
+		if err != nil {
+			return err
+		}
+		if err := doc.SetValueAt(computed.Field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ticketOverdue is whether the ticket was due before now and is not yet solved or closed.
+func ticketOverdue(doc Model, c ComputeContext) (any, error) {
+	t, ok := doc.(*Ticket)
+	if !ok {
+		return nil, ErrFieldType
+	}
+	if t.DueAt.IsZero() || t.Status == TicketStatusSolved || t.Status == TicketStatusClosed {
+		return false, nil
+	}
+	return t.DueAt.Before(c.Now), nil
+}
+
+// countRelated counts the documents related by the named relation.
+func countRelated(relation string) func(Model, ComputeContext) (any, error) {
+	return func(doc Model, c ComputeContext) (any, error) {
+		related, err := c.Related(doc, relation)
+		if err != nil {
+			return nil, err
+		}
+		return len(related), nil
+	}
+}
+
+// IsComputed is whether field is a computed field of the document type of doc.
+func IsComputed(doc Model, field string) bool {
+	for _, computed := range ComputedFields {
+		if computed.Field == field && computed.Model.DocumentType() == doc.DocumentType() {
+			return true
+		}
+	}
+	return false
+}
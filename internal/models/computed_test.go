@@ -0,0 +1,84 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC)
+	related := func(doc models.Model, relation string) ([]models.Model, error) {
+		if relation == "submitted_tickets" {
+			return []models.Model{&models.Ticket{}, &models.Ticket{}}, nil
+		}
+		return nil, nil
+	}
+
+	for _, tc := range []struct {
+		name     string
+		doc      models.Model
+		field    string
+		expected any
+	}{
+		{
+			name:     "ticket_overdue",
+			doc:      &models.Ticket{DueAt: models.MustParseTimestamp("2016-07-31T02:37:50 -10:00"), Status: "open"},
+			field:    "overdue",
+			expected: true,
+		},
+		{
+			name:     "ticket_not_yet_due",
+			doc:      &models.Ticket{DueAt: models.MustParseTimestamp("2016-08-15T05:37:32 -10:00"), Status: "open"},
+			field:    "overdue",
+			expected: false,
+		},
+		{
+			name:     "ticket_solved",
+			doc:      &models.Ticket{DueAt: models.MustParseTimestamp("2016-07-31T02:37:50 -10:00"), Status: "solved"},
+			field:    "overdue",
+			expected: false,
+		},
+		{
+			name:     "ticket_without_due_date",
+			doc:      &models.Ticket{Status: "open"},
+			field:    "overdue",
+			expected: false,
+		},
+		{
+			name:     "user_submitted_ticket_count",
+			doc:      &models.User{},
+			field:    "submitted_ticket_count",
+			expected: 2,
+		},
+		{
+			name:     "user_assigned_ticket_count",
+			doc:      &models.User{},
+			field:    "assigned_ticket_count",
+			expected: 0,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.NilError(t, models.Compute(tc.doc, models.ComputeContext{Now: now, Related: related}))
+			value, err := tc.doc.ValueAt(tc.field)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestIsComputed(t *testing.T) {
+	t.Parallel()
+
+	assert.Assert(t, models.IsComputed(&models.Ticket{}, "overdue"))
+	assert.Assert(t, models.IsComputed(&models.Organization{}, "user_count"))
+	assert.Assert(t, !models.IsComputed(&models.Ticket{}, "user_count"))
+	assert.Assert(t, !models.IsComputed(&models.User{}, "name"))
+}
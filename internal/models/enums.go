@@ -0,0 +1,166 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrInvalidValue = errors.New("invalid value")
+
+// Enum is a string field with a fixed set of valid values.
+type Enum interface {
+	fmt.Stringer
+
+	// Values returns the valid values, in the order they are presented to users.
+	Values() []string
+}
+
+// ValidEnum reports whether e holds one of its Values.
+// The empty value is valid, because the field may be missing from a document.
+func ValidEnum(e Enum) bool {
+	return e.String() == "" || slices.Contains(e.Values(), e.String())
+}
+
+// EnumValues returns the valid values of the field in m, or false if the field is not an Enum.
+func EnumValues(m Model, field string) ([]string, bool) {
+	value, err := m.ValueAt(field)
+	if err != nil {
+		return nil, false
+	}
+	if e, ok := value.(Enum); ok {
+		return e.Values(), true
+	}
+	return nil, false
+}
+
+// Validate returns an error wrapping ErrInvalidValue for each enumerated field in m that holds an invalid value,
+// and for each field in the data of m with the name of a computed field, which is not searched, see Shadowed.
+func Validate(m Model) []error {
+	errs := []error{}
+	fields := m.Fields()
+	for el := fields.Front(); el != nil; el = el.Next() {
+		if e, ok := m.ValueAtIdx(el.Value).(Enum); ok && !ValidEnum(e) {
+			errs = append(errs, fmt.Errorf(
+				"%w: %s %s has %s %q, expected one of %q",
+				ErrInvalidValue, m.DocumentType(), m.StringID(), el.Key, e.String(), e.Values(),
+			))
+		}
+	}
+	for el := fields.Front(); el != nil; el = el.Next() {
+		if _, exists := m.ExtraFields()[el.Key]; exists && IsComputed(m, el.Key) {
+			errs = append(errs, fmt.Errorf(
+				"%w: %s %s has %s in its data, which is a computed field, so the value in the data is kept but not searched",
+				ErrInvalidValue, m.DocumentType(), m.StringID(), el.Key,
+			))
+		}
+	}
+	return errs
+}
+
+type TicketType string
+
+const (
+	TicketTypeProblem  TicketType = "problem"
+	TicketTypeIncident TicketType = "incident"
+	TicketTypeQuestion TicketType = "question"
+	TicketTypeTask     TicketType = "task"
+)
+
+func (t TicketType) String() string { return string(t) }
+
+func (TicketType) Values() []string {
+	return []string{
+		string(TicketTypeProblem),
+		string(TicketTypeIncident),
+		string(TicketTypeQuestion),
+		string(TicketTypeTask),
+	}
+}
+
+type TicketPriority string
+
+const (
+	TicketPriorityUrgent TicketPriority = "urgent"
+	TicketPriorityHigh   TicketPriority = "high"
+	TicketPriorityNormal TicketPriority = "normal"
+	TicketPriorityLow    TicketPriority = "low"
+)
+
+func (p TicketPriority) String() string { return string(p) }
+
+func (TicketPriority) Values() []string {
+	return []string{
+		string(TicketPriorityUrgent),
+		string(TicketPriorityHigh),
+		string(TicketPriorityNormal),
+		string(TicketPriorityLow),
+	}
+}
+
+type TicketStatus string
+
+const (
+	TicketStatusNew     TicketStatus = "new"
+	TicketStatusOpen    TicketStatus = "open"
+	TicketStatusPending TicketStatus = "pending"
+	TicketStatusHold    TicketStatus = "hold"
+	TicketStatusSolved  TicketStatus = "solved"
+	TicketStatusClosed  TicketStatus = "closed"
+)
+
+func (s TicketStatus) String() string { return string(s) }
+
+func (TicketStatus) Values() []string {
+	return []string{
+		string(TicketStatusNew),
+		string(TicketStatusOpen),
+		string(TicketStatusPending),
+		string(TicketStatusHold),
+		string(TicketStatusSolved),
+		string(TicketStatusClosed),
+	}
+}
+
+// TicketVia is the channel the ticket was created through.
+type TicketVia string
+
+const (
+	TicketViaWeb    TicketVia = "web"
+	TicketViaEmail  TicketVia = "email"
+	TicketViaChat   TicketVia = "chat"
+	TicketViaVoice  TicketVia = "voice"
+	TicketViaAPI    TicketVia = "api"
+	TicketViaMobile TicketVia = "mobile"
+)
+
+func (v TicketVia) String() string { return string(v) }
+
+func (TicketVia) Values() []string {
+	return []string{
+		string(TicketViaWeb),
+		string(TicketViaEmail),
+		string(TicketViaChat),
+		string(TicketViaVoice),
+		string(TicketViaAPI),
+		string(TicketViaMobile),
+	}
+}
+
+type UserRole string
+
+const (
+	UserRoleEndUser UserRole = "end-user"
+	UserRoleAgent   UserRole = "agent"
+	UserRoleAdmin   UserRole = "admin"
+)
+
+func (r UserRole) String() string { return string(r) }
+
+func (UserRole) Values() []string {
+	return []string{
+		string(UserRoleEndUser),
+		string(UserRoleAgent),
+		string(UserRoleAdmin),
+	}
+}
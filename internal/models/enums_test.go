@@ -0,0 +1,71 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name           string
+		model          models.Model
+		expectedErrors int
+	}{
+		{
+			name:  "ticket_valid",
+			model: &models.Ticket{Type: "task", Priority: "low", Status: "open", Via: "web"},
+		},
+		{
+			name:  "ticket_missing",
+			model: &models.Ticket{},
+		},
+		{
+			name:           "ticket_invalid",
+			model:          &models.Ticket{Type: "tsak", Priority: "low", Status: "opne", Via: "web"},
+			expectedErrors: 2,
+		},
+		{
+			name:           "user_invalid",
+			model:          &models.User{Role: "administrator"},
+			expectedErrors: 1,
+		},
+		{
+			name:  "organization",
+			model: &models.Organization{Name: "anything"},
+		},
+		{
+			name:           "computed_field_in_data",
+			model:          &models.Ticket{Extras: models.Extras{"overdue": "yes", "region": "EU"}},
+			expectedErrors: 1,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := models.Validate(tc.model)
+			assert.Equal(t, tc.expectedErrors, len(errs))
+			for _, err := range errs {
+				assert.ErrorIs(t, err, models.ErrInvalidValue)
+			}
+		})
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	t.Parallel()
+
+	values, ok := models.EnumValues(&models.Ticket{}, "priority")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, []string{"urgent", "high", "normal", "low"}, values)
+
+	_, ok = models.EnumValues(&models.Ticket{}, "subject")
+	assert.Assert(t, !ok)
+
+	_, ok = models.EnumValues(&models.Ticket{}, "fake")
+	assert.Assert(t, !ok)
+}
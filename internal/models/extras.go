@@ -0,0 +1,135 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// Extras holds the fields of a document that are not declared in its Model, keyed by name.
+// Values are decoded as encoding/json decodes into any: objects as map[string]any, arrays as []any.
+type Extras map[string]any
+
+// Flatten returns the scalar values in the extra fields keyed by their dotted path, e.g. custom_fields.region.
+// The values of an array are all returned under the path of the array.
+func (e Extras) Flatten() map[string][]any {
+	out := map[string][]any{}
+	for name, value := range e {
+		flatten(out, name, value)
+	}
+	return out
+}
+
+func flatten(out map[string][]any, path string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, child := range v {
+			flatten(out, path+"."+name, child)
+		}
+	case []any:
+		for _, child := range v {
+			flatten(out, path, child)
+		}
+	default:
+		out[path] = append(out[path], v)
+	}
+}
+
+// Paths returns the dotted paths of the scalar values in the extra fields, sorted.
+func (e Extras) Paths() []string {
+	paths := ExtraFieldSet{}
+	for path := range e.Flatten() {
+		paths[path]++
+	}
+	return paths.Sorted()
+}
+
+// ExtraFieldSet is a set of the dotted paths of the extra fields found in documents.
+// It counts the documents with each path, so a path is removed with the last document that has it.
+type ExtraFieldSet map[string]int
+
+// Add adds the paths of the extra fields in m to the set, except those Shadowed by a computed field.
+func (s ExtraFieldSet) Add(m Model) {
+	for path := range m.ExtraFields().Flatten() {
+		if !Shadowed(m, path) {
+			s[path]++
+		}
+	}
+}
+
+// Remove removes the paths of the extra fields in m from the set, unless another document has them.
+func (s ExtraFieldSet) Remove(m Model) {
+	for path := range m.ExtraFields().Flatten() {
+		if Shadowed(m, path) {
+			continue
+		}
+		s[path]--
+		if s[path] <= 0 {
+			delete(s, path)
+		}
+	}
+}
+
+// Has reports whether a document in the set has the path.
+func (s ExtraFieldSet) Has(path string) bool {
+	return s[path] > 0
+}
+
+// Sorted returns the paths in the set, sorted.
+func (s ExtraFieldSet) Sorted() []string {
+	paths := make([]string, 0, len(s))
+	for path := range s {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// Shadowed reports whether the extra field of m at the dotted path is in a field with the name of a computed field of m,
+// so it is kept in the document, but is not searched, as the computed field is searched by that name instead.
+func Shadowed(m Model, path string) bool {
+	name, _, _ := strings.Cut(path, ".")
+	return IsComputed(m, name)
+}
+
+// unmarshalExtras returns the fields in the json object data that are not declared in m, or nil if there are none.
+// A field with the name of a computed field is kept, as computed fields are not read from the data, see Shadowed.
+func unmarshalExtras(data []byte, m Model) (Extras, error) {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	known := m.Fields()
+	var extras Extras
+	for name, value := range raw {
+		if _, exists := known.Get(name); exists && !IsComputed(m, name) {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, err
+		}
+		if extras == nil {
+			extras = Extras{}
+		}
+		extras[name] = v
+	}
+	return extras, nil
+}
+
+// marshalWithExtras adds the extra fields to the json object data.
+func marshalWithExtras(data []byte, extras Extras) ([]byte, error) {
+	if len(extras) == 0 {
+		return data, nil
+	}
+	buf, err := json.Marshal(extras)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSuffix(bytes.TrimSpace(data), []byte("}"))
+	if len(bytes.TrimSpace(data)) > 1 {
+		data = append(data, ',')
+	}
+	return append(data, buf[1:]...), nil
+}
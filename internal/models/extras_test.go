@@ -0,0 +1,126 @@
+package models_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestExtrasRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const data = `{"_id":12,"name":"Limozen","custom_fields":{"region":"EU","score":4.5},"fields":[{"id":1,"value":null}]}`
+
+	var org models.Organization
+	assert.NilError(t, json.Unmarshal([]byte(data), &org))
+	assert.Equal(t, 12, org.ID)
+	assert.Equal(t, "Limozen", org.Name)
+	assert.DeepEqual(t, models.Extras{
+		"custom_fields": map[string]any{"region": "EU", "score": 4.5},
+		"fields":        []any{map[string]any{"id": 1.0, "value": nil}},
+	}, org.Extras)
+
+	buf, err := json.Marshal(org)
+	assert.NilError(t, err)
+
+	var roundTripped models.Organization
+	assert.NilError(t, json.Unmarshal(buf, &roundTripped))
+	assert.DeepEqual(t, org, roundTripped)
+}
+
+func TestNoExtras(t *testing.T) {
+	t.Parallel()
+
+	var user models.User
+	assert.NilError(t, json.Unmarshal([]byte(`{"_id":12,"name":"Key Mendez"}`), &user))
+	assert.Assert(t, user.Extras == nil)
+}
+
+func TestComputedFieldInData(t *testing.T) {
+	t.Parallel()
+
+	// computed fields are not read from the data, so a field with the name of one is kept with the extra fields
+	const data = `{"_id":12,"name":"Key Mendez","submitted_ticket_count":7,"custom_fields":{"region":"EU"}}`
+
+	var user models.User
+	assert.NilError(t, json.Unmarshal([]byte(data), &user))
+	assert.Equal(t, 0, user.SubmittedTicketCount)
+	assert.DeepEqual(t, models.Extras{
+		"submitted_ticket_count": 7.0,
+		"custom_fields":          map[string]any{"region": "EU"},
+	}, user.Extras)
+	assert.Assert(t, models.Shadowed(&user, "submitted_ticket_count"))
+	assert.Assert(t, !models.Shadowed(&user, "custom_fields.region"))
+
+	buf, err := json.Marshal(user)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(buf), `"submitted_ticket_count":7`), string(buf))
+
+	// but is not one of the extra fields searched
+	s := models.ExtraFieldSet{}
+	s.Add(&user)
+	assert.DeepEqual(t, []string{"custom_fields.region"}, s.Sorted())
+	s.Remove(&user)
+	assert.DeepEqual(t, []string{}, s.Sorted())
+}
+
+func TestExtrasFlatten(t *testing.T) {
+	t.Parallel()
+
+	extras := models.Extras{
+		"brand":         "Initech",
+		"custom_fields": map[string]any{"region": "EU", "nested": map[string]any{"level": 2.0}},
+		"fields":        []any{map[string]any{"id": 1.0, "value": "gold"}, map[string]any{"id": 2.0, "value": nil}},
+	}
+
+	assert.DeepEqual(t, map[string][]any{
+		"brand":                      {"Initech"},
+		"custom_fields.region":       {"EU"},
+		"custom_fields.nested.level": {2.0},
+		"fields.id":                  {1.0, 2.0},
+		"fields.value":               {"gold", nil},
+	}, extras.Flatten())
+
+	assert.DeepEqual(t, []string{
+		"brand",
+		"custom_fields.nested.level",
+		"custom_fields.region",
+		"fields.id",
+		"fields.value",
+	}, extras.Paths())
+}
+
+func TestStringOfExtras(t *testing.T) {
+	t.Parallel()
+
+	s, err := models.StringOf(&models.Organization{
+		Extras: models.Extras{"custom_fields": map[string]any{"region": "EU"}, "fields": []any{1.0, 2.0}},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(s, fmt.Sprintf("%-20s\t%s\n", "custom_fields.region", `"EU"`)), s)
+	assert.Assert(t, strings.Contains(s, fmt.Sprintf("%-20s\t%s\n", "fields", `[1,2]`)), s)
+}
+
+func TestExtraFieldSet(t *testing.T) {
+	t.Parallel()
+
+	both := &models.Ticket{Extras: models.Extras{"region": "EU", "score": 4.5}}
+	one := &models.Ticket{Extras: models.Extras{"region": "US"}}
+
+	s := models.ExtraFieldSet{}
+	s.Add(both)
+	s.Add(one)
+	assert.DeepEqual(t, []string{"region", "score"}, s.Sorted())
+
+	s.Remove(both)
+	assert.DeepEqual(t, []string{"region"}, s.Sorted())
+	assert.Assert(t, s.Has("region"))
+	assert.Assert(t, !s.Has("score"))
+
+	s.Remove(one)
+	assert.DeepEqual(t, []string{}, s.Sorted())
+}
@@ -0,0 +1,13 @@
+package models
+
+import "github.com/elliotchance/orderedmap/v2"
+
+// newFieldTable maps the field names to their index, in order.
+// It backs the generated Fields methods, so the map is built once per type rather than per call.
+func newFieldTable(names ...string) *orderedmap.OrderedMap[string, int] {
+	fields := orderedmap.NewOrderedMap[string, int]()
+	for i, name := range names {
+		fields.Set(name, i)
+	}
+	return fields
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"strconv"
+)
+
+//go:generate go run ./accessorgen -type Group
+
+type Group struct {
+	ID        int       `json:"_id"`
+	URL       string    `json:"url"`
+	Name      string    `json:"name"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+	Deleted   bool      `json:"deleted"`
+
+	// Extras holds the fields in the data that are not declared above.
+	Extras Extras `json:"-"`
+}
+
+func (*Group) DocumentType() string {
+	return "Group"
+}
+
+func (g *Group) StringID() string {
+	if g == nil {
+		g = &Group{}
+	}
+	return strconv.Itoa(g.ID)
+}
+
+func (g *Group) String() (string, error) {
+	if g == nil {
+		g = &Group{}
+	}
+	return StringOf(g)
+}
+
+// GroupSliceToModelsSlice converts a slice of Group to a slice of Model.
+func GroupSliceToModelsSlice(in []Group) []Model {
+	out := make([]Model, 0, len(in))
+	for _, v := range in {
+		v := v
+		out = append(out, &v)
+	}
+	return out
+}
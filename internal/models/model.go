@@ -6,16 +6,73 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elliotchance/orderedmap/v2"
 	"github.com/google/uuid"
 )
 
-var ErrFieldNotFound = fmt.Errorf("field not found")
+var (
+	ErrFieldNotFound = fmt.Errorf("field not found")
+	ErrFieldType     = fmt.Errorf("invalid type for field")
+)
+
+// RelatedModel is a Model that is returned because it is related to another Model.
+type RelatedModel struct {
+	Model
+	// Relation is the name of the relation to the other Model.
+	Relation string
+}
+
+// Clone returns a copy of the RelatedModel with a shallow copy of the Model.
+func (r *RelatedModel) Clone() Model {
+	return &RelatedModel{Model: r.Model.Clone(), Relation: r.Relation}
+}
+
+// SourcedModel is a Model from one of several named sources of documents, such as the exports of different instances,
+// whose _ids may be the same as those of the documents from the others.
+type SourcedModel struct {
+	Model
+	// Source is the name of the source of the Model.
+	Source string
+}
+
+// Clone returns a copy of the SourcedModel with a shallow copy of the Model.
+func (s *SourcedModel) Clone() Model {
+	return &SourcedModel{Model: s.Model.Clone(), Source: s.Source}
+}
+
+// SourceOf returns the name of the source of m, looking inside a RelatedModel, or "" if it is not a SourcedModel.
+func SourceOf(m Model) string {
+	switch m := m.(type) {
+	case *RelatedModel:
+		return SourceOf(m.Model)
+	case *SourcedModel:
+		return m.Source
+	default:
+		return ""
+	}
+}
+
+// Unwrap returns the Model inside the RelatedModel and SourcedModel m may be wrapped in, or m if it is neither.
+func Unwrap(m Model) Model {
+	switch m := m.(type) {
+	case *RelatedModel:
+		return Unwrap(m.Model)
+	case *SourcedModel:
+		return Unwrap(m.Model)
+	default:
+		return m
+	}
+}
 
-type ContainedModel struct {
-	Model Model
-	Field string
+// Relate wraps each Model in a RelatedModel with the given relation name.
+func Relate(relation string, in []Model) []Model {
+	out := make([]Model, 0, len(in))
+	for _, m := range in {
+		out = append(out, &RelatedModel{Model: m, Relation: relation})
+	}
+	return out
 }
 
 type Model interface {
@@ -35,26 +92,62 @@ type Model interface {
 	// It returns ErrFieldNotFound if the field does not exist.
 	ValueAt(field string) (any, error)
 
-	// Contains returns a slice of ContainedModels that the Model contains.
-	Contains() []ContainedModel
+	// SetValueAtIdx sets the value of the field in the Model at i.
+	// It returns ErrFieldNotFound if there is no such field and ErrFieldType if value has the wrong type.
+	SetValueAtIdx(i int, value any) error
+
+	// SetValueAt sets the value of the field in the Model.
+	// It returns ErrFieldNotFound if the field does not exist and ErrFieldType if value has the wrong type.
+	SetValueAt(field string, value any) error
+
+	// ExtraFields returns the fields of the document that are not declared in the Model.
+	ExtraFields() Extras
+
+	// Clone returns a shallow copy of the Model, which can be changed without changing the Model.
+	// Slices and maps are shared, so they must be replaced rather than changed in place.
+	Clone() Model
 }
 
 // StringOf returns a string representation of the Model.
 func StringOf(t Model) (string, error) {
+	return StringOfIn(t, nil)
+}
+
+// StringOfIn returns a string representation of the Model with timestamps shown in loc.
+// A nil loc shows timestamps with the offset they were written with.
+func StringOfIn(t Model, loc *time.Location) (string, error) {
 	var out strings.Builder
 	m := t.Fields()
 	for el := m.Front(); el != nil; el = el.Next() {
 		value := t.ValueAtIdx(el.Value)
+		if ts, ok := value.(Timestamp); ok {
+			value = ts.FormatIn(loc)
+		}
 		buf, err := json.Marshal(value)
 		if err != nil {
 			return "", fmt.Errorf("failed to marshal value %v: %w", value, err)
 		}
 		_, _ = fmt.Fprintf(&out, "%-20s\t%s\n", el.Key, buf)
 	}
+
+	extras := t.ExtraFields().Flatten()
+	for _, path := range t.ExtraFields().Paths() {
+		// a single value is shown as is, rather than in an array
+		var value any = extras[path]
+		if len(extras[path]) == 1 {
+			value = extras[path][0]
+		}
+		buf, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal value %v: %w", value, err)
+		}
+		_, _ = fmt.Fprintf(&out, "%-20s\t%s\n", path, buf)
+	}
 	return out.String(), nil
 }
 
 // FieldSlice returns a slice of the fields in the Model.
+// The extra fields of the document are not included.
 func FieldSlice(t Model) []string {
 	fieldSet := t.Fields()
 	fieldSlice := make([]string, 0, fieldSet.Len())
@@ -87,6 +180,23 @@ func ValueContains(val any, query string) bool {
 		}
 		return parsed == value
 
+	case Timestamp:
+		return value.Matches(query)
+
+	case Enum:
+		return value.String() == query
+
+	// extra fields are decoded from json without a declared type
+	case float64:
+		parsed, err := strconv.ParseFloat(query, 64)
+		if err != nil {
+			return false
+		}
+		return parsed == value
+
+	case nil:
+		return query == ""
+
 	// other types don't appear in the data, extend this when they do
 	default:
 		return false
@@ -31,6 +31,8 @@ func TestFieldsSlice(t *testing.T) {
 				"details",
 				"shared_tickets",
 				"tags",
+				"user_count",
+				"ticket_count",
 			},
 		},
 		{
@@ -46,6 +48,8 @@ func TestFieldsSlice(t *testing.T) {
 				"details",
 				"shared_tickets",
 				"tags",
+				"user_count",
+				"ticket_count",
 			},
 		},
 		{
@@ -71,6 +75,8 @@ func TestFieldsSlice(t *testing.T) {
 				"tags",
 				"suspended",
 				"role",
+				"submitted_ticket_count",
+				"assigned_ticket_count",
 			},
 		},
 		{
@@ -96,6 +102,8 @@ func TestFieldsSlice(t *testing.T) {
 				"tags",
 				"suspended",
 				"role",
+				"submitted_ticket_count",
+				"assigned_ticket_count",
 			},
 		},
 		{
@@ -114,10 +122,12 @@ func TestFieldsSlice(t *testing.T) {
 				"submitter_id",
 				"assignee_id",
 				"organization_id",
+				"group_id",
 				"tags",
 				"has_incidents",
 				"due_at",
 				"via",
+				"overdue",
 			},
 		},
 		{
@@ -136,10 +146,48 @@ func TestFieldsSlice(t *testing.T) {
 				"submitter_id",
 				"assignee_id",
 				"organization_id",
+				"group_id",
 				"tags",
 				"has_incidents",
 				"due_at",
 				"via",
+				"overdue",
+			},
+		},
+		{
+			name:  "group_zero",
+			model: new(models.Group),
+			expectedFields: []string{
+				"_id",
+				"url",
+				"name",
+				"created_at",
+				"updated_at",
+				"deleted",
+			},
+		},
+		{
+			name:  "comment_zero",
+			model: new(models.Comment),
+			expectedFields: []string{
+				"_id",
+				"ticket_id",
+				"author_id",
+				"body",
+				"public",
+				"created_at",
+				"via",
+			},
+		},
+		{
+			name:  "audit_zero",
+			model: new(models.Audit),
+			expectedFields: []string{
+				"_id",
+				"ticket_id",
+				"author_id",
+				"created_at",
+				"via",
 			},
 		},
 	} {
@@ -240,3 +288,89 @@ func TestValueAt(t *testing.T) {
 		})
 	}
 }
+
+func TestSetValueAt(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		model         models.Model
+		field         string
+		value         any
+		expectedError error
+	}{
+		{
+			name:  "organization_name",
+			model: new(models.Organization),
+			field: "name",
+			value: "Limozen",
+		},
+		{
+			name:  "ticket_id",
+			model: new(models.Ticket),
+			field: "_id",
+			value: dummyUUID,
+		},
+		{
+			name:  "user_tags",
+			model: new(models.User),
+			field: "tags",
+			value: []string{"a", "b", "c"},
+		},
+		{
+			name:  "user_last_login_at",
+			model: new(models.User),
+			field: "last_login_at",
+			value: models.MustParseTimestamp("2016-04-28T11:19:34 -10:00"),
+		},
+		{
+			name:          "user_fake",
+			model:         new(models.User),
+			field:         "fake",
+			value:         12,
+			expectedError: models.ErrFieldNotFound,
+		},
+		{
+			name:          "ticket_id_wrong_type",
+			model:         new(models.Ticket),
+			field:         "_id",
+			value:         12,
+			expectedError: models.ErrFieldType,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.model.SetValueAt(tc.field, tc.value)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+
+			value, err := tc.model.ValueAt(tc.field)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.value, value)
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	ticket := &models.Ticket{ID: dummyUUID, Subject: "A Problem", Tags: []string{"a"}}
+	clone := ticket.Clone()
+	assert.DeepEqual(t, models.Model(ticket), clone)
+
+	assert.NilError(t, clone.SetValueAt("subject", "Another Problem"))
+	assert.Equal(t, "A Problem", ticket.Subject)
+
+	related := &models.RelatedModel{Model: ticket, Relation: "tickets"}
+	relatedClone, ok := related.Clone().(*models.RelatedModel)
+	assert.Assert(t, ok)
+	assert.Equal(t, "tickets", relatedClone.Relation)
+	assert.Assert(t, relatedClone.Model != related.Model)
+
+	assert.DeepEqual(t, models.Model(&models.User{}), (*models.User)(nil).Clone())
+}
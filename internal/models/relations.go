@@ -0,0 +1,126 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elliotchance/orderedmap/v2"
+)
+
+var (
+	ErrRelationNotFound  = errors.New("relation not found")
+	ErrDuplicateRelation = errors.New("duplicate relation")
+)
+
+// Relation describes an edge from a document of type From to the documents of type To
+// whose ToField holds the same value as the FromField of the document.
+//
+// "Has-many" relations go from the _id of the document, e.g. a user's submitted tickets
+// are the tickets whose submitter_id is the user's _id.
+// "Belongs-to" relations go to the _id of the related documents, e.g. a ticket's submitter
+// is the user whose _id is the ticket's submitter_id.
+type Relation struct {
+	// Name is the name of the relation, e.g. "submitted_tickets".
+	Name      string
+	From      Model
+	FromField string
+	To        Model
+	ToField   string
+}
+
+// Registry holds the relations between document types.
+type Registry struct {
+	// relations maps the DocumentType of From to the relations from it, in the order they were registered.
+	relations map[string][]Relation
+}
+
+// NewRegistry validates that the fields of each relation exist
+// and that the relation names are unique for each document type.
+func NewRegistry(relations ...Relation) (*Registry, error) {
+	r := &Registry{relations: map[string][]Relation{}}
+	for _, relation := range relations {
+		if _, exists := fieldsOf(relation.From).Get(relation.FromField); !exists {
+			return nil, fmt.Errorf("%w: %s in %s", ErrFieldNotFound, relation.FromField, relation.From.DocumentType())
+		}
+		if _, exists := fieldsOf(relation.To).Get(relation.ToField); !exists {
+			return nil, fmt.Errorf("%w: %s in %s", ErrFieldNotFound, relation.ToField, relation.To.DocumentType())
+		}
+		docType := relation.From.DocumentType()
+		if _, exists := r.Lookup(relation.From, relation.Name); exists {
+			return nil, fmt.Errorf("%w: %s from %s", ErrDuplicateRelation, relation.Name, docType)
+		}
+		r.relations[docType] = append(r.relations[docType], relation)
+	}
+	return r, nil
+}
+
+// From returns the relations from documents of the same type as m.
+func (r *Registry) From(m Model) []Relation {
+	return r.relations[m.DocumentType()]
+}
+
+// Lookup returns the relation with the given name from documents of the same type as m.
+func (r *Registry) Lookup(m Model, name string) (Relation, bool) {
+	for _, relation := range r.relations[m.DocumentType()] {
+		if relation.Name == name {
+			return relation, true
+		}
+	}
+	return Relation{}, false
+}
+
+// fieldTables are the field tables of the document types, by DocumentType.
+// NewRegistry reads them directly, rather than through Model.Fields, so that Relations is initialised after them:
+// the order of initialisation follows references to package level vars, but not through interface calls.
+var fieldTables = map[string]*orderedmap.OrderedMap[string, int]{
+	"Organization": organizationFields,
+	"Ticket":       ticketFields,
+	"User":         userFields,
+	"Group":        groupFields,
+	"Comment":      commentFields,
+	"Audit":        auditFields,
+}
+
+// fieldsOf returns the field table of the type of m.
+func fieldsOf(m Model) *orderedmap.OrderedMap[string, int] {
+	if fields, exists := fieldTables[m.DocumentType()]; exists {
+		return fields
+	}
+	// the fields of a Model from outside this package are initialised before it can call NewRegistry
+	return m.Fields()
+}
+
+// mustNewRegistry is like NewRegistry but panics if the relations are invalid.
+func mustNewRegistry(relations ...Relation) *Registry {
+	r, err := NewRegistry(relations...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Relations are the relations between the document types in the data.
+var Relations = mustNewRegistry(
+	Relation{Name: "tickets", From: &Organization{}, FromField: "_id", To: &Ticket{}, ToField: "organization_id"},
+	Relation{Name: "users", From: &Organization{}, FromField: "_id", To: &User{}, ToField: "organization_id"},
+
+	Relation{Name: "submitter", From: &Ticket{}, FromField: "submitter_id", To: &User{}, ToField: "_id"},
+	Relation{Name: "assignee", From: &Ticket{}, FromField: "assignee_id", To: &User{}, ToField: "_id"},
+	Relation{Name: "organization", From: &Ticket{}, FromField: "organization_id", To: &Organization{}, ToField: "_id"},
+	Relation{Name: "group", From: &Ticket{}, FromField: "group_id", To: &Group{}, ToField: "_id"},
+	Relation{Name: "comments", From: &Ticket{}, FromField: "_id", To: &Comment{}, ToField: "ticket_id"},
+	Relation{Name: "audits", From: &Ticket{}, FromField: "_id", To: &Audit{}, ToField: "ticket_id"},
+
+	Relation{Name: "submitted_tickets", From: &User{}, FromField: "_id", To: &Ticket{}, ToField: "submitter_id"},
+	Relation{Name: "assigned_tickets", From: &User{}, FromField: "_id", To: &Ticket{}, ToField: "assignee_id"},
+	Relation{Name: "organization", From: &User{}, FromField: "organization_id", To: &Organization{}, ToField: "_id"},
+	Relation{Name: "comments", From: &User{}, FromField: "_id", To: &Comment{}, ToField: "author_id"},
+
+	Relation{Name: "tickets", From: &Group{}, FromField: "_id", To: &Ticket{}, ToField: "group_id"},
+
+	Relation{Name: "ticket", From: &Comment{}, FromField: "ticket_id", To: &Ticket{}, ToField: "_id"},
+	Relation{Name: "author", From: &Comment{}, FromField: "author_id", To: &User{}, ToField: "_id"},
+
+	Relation{Name: "ticket", From: &Audit{}, FromField: "ticket_id", To: &Ticket{}, ToField: "_id"},
+	Relation{Name: "author", From: &Audit{}, FromField: "author_id", To: &User{}, ToField: "_id"},
+)
@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestNewRegistry(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		relations     []models.Relation
+		expectedError error
+	}{
+		{
+			name: "valid",
+			relations: []models.Relation{
+				{Name: "tickets", From: &models.Organization{}, FromField: "_id", To: &models.Ticket{}, ToField: "organization_id"},
+			},
+		},
+		{
+			name: "from_field_not_found",
+			relations: []models.Relation{
+				{Name: "tickets", From: &models.Organization{}, FromField: "fake", To: &models.Ticket{}, ToField: "organization_id"},
+			},
+			expectedError: models.ErrFieldNotFound,
+		},
+		{
+			name: "to_field_not_found",
+			relations: []models.Relation{
+				{Name: "tickets", From: &models.Organization{}, FromField: "_id", To: &models.Ticket{}, ToField: "fake"},
+			},
+			expectedError: models.ErrFieldNotFound,
+		},
+		{
+			name: "duplicate",
+			relations: []models.Relation{
+				{Name: "tickets", From: &models.Organization{}, FromField: "_id", To: &models.Ticket{}, ToField: "organization_id"},
+				{Name: "tickets", From: &models.Organization{}, FromField: "_id", To: &models.User{}, ToField: "organization_id"},
+			},
+			expectedError: models.ErrDuplicateRelation,
+		},
+		{
+			name: "same_name_different_types",
+			relations: []models.Relation{
+				{Name: "organization", From: &models.Ticket{}, FromField: "organization_id", To: &models.Organization{}, ToField: "_id"},
+				{Name: "organization", From: &models.User{}, FromField: "organization_id", To: &models.Organization{}, ToField: "_id"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := models.NewRegistry(tc.relations...)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	t.Parallel()
+
+	relation, exists := models.Relations.Lookup(&models.Ticket{}, "submitter")
+	assert.Assert(t, exists)
+	assert.Equal(t, "submitter_id", relation.FromField)
+	assert.Equal(t, "User", relation.To.DocumentType())
+
+	_, exists = models.Relations.Lookup(&models.Organization{}, "submitter")
+	assert.Assert(t, !exists)
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TimestampLayout is the layout of timestamps in the Zendesk exports, e.g. 2016-04-28T11:19:34 -10:00.
+// It is not RFC3339 because of the space before the offset.
+const TimestampLayout = "2006-01-02T15:04:05 -07:00"
+
+// The layouts of the date prefixes that a Timestamp can be queried with, from the most to least precise.
+const (
+	DayLayout   = "2006-01-02"
+	MonthLayout = "2006-01"
+	YearLayout  = "2006"
+)
+
+// Timestamp is a point in time as it appears in the data.
+// It keeps the offset it was written with, so it marshals back to the original string.
+// The zero value represents an empty or missing timestamp.
+type Timestamp struct {
+	time.Time
+}
+
+// ParseTimestamp parses a string in TimestampLayout. The empty string parses to the zero Timestamp.
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+	t, err := time.Parse(TimestampLayout, s)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: %w", s, err)
+	}
+	return Timestamp{Time: t}, nil
+}
+
+// MustParseTimestamp is like ParseTimestamp but panics if the string cannot be parsed.
+func MustParseTimestamp(s string) Timestamp {
+	t, err := ParseTimestamp(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// String returns the timestamp in TimestampLayout, or the empty string for the zero Timestamp.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(TimestampLayout)
+}
+
+// InLocation returns the timestamp in loc, whose date, month and year terms are those of the same instant in loc.
+// A nil location, or the zero Timestamp, is returned as is.
+func (t Timestamp) InLocation(loc *time.Location) Timestamp {
+	if t.IsZero() || loc == nil {
+		return t
+	}
+	return Timestamp{Time: t.Time.In(loc)}
+}
+
+// SetLocation changes each timestamp of m to be in loc, so it is searched by the dates it is shown with in loc.
+// A nil location leaves them in the offset they were written with.
+func SetLocation(m Model, loc *time.Location) {
+	if loc == nil {
+		return
+	}
+	fields := m.Fields()
+	for el := fields.Front(); el != nil; el = el.Next() {
+		if ts, ok := m.ValueAtIdx(el.Value).(Timestamp); ok {
+			// the value has the type of the field, so it cannot fail
+			_ = m.SetValueAtIdx(el.Value, ts.InLocation(loc))
+		}
+	}
+}
+
+// FormatIn returns the timestamp formatted in TimestampLayout in the given location.
+// A nil location keeps the original offset.
+func (t Timestamp) FormatIn(loc *time.Location) string {
+	if t.IsZero() || loc == nil {
+		return t.String()
+	}
+	return t.Time.In(loc).Format(TimestampLayout)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// Terms returns the strings the timestamp can be searched by: the full timestamp,
+// and its date, month and year in the offset it was written with.
+func (t Timestamp) Terms() []string {
+	if t.IsZero() {
+		return []string{""}
+	}
+	return []string{
+		t.String(),
+		t.Format(DayLayout),
+		t.Format(MonthLayout),
+		t.Format(YearLayout),
+	}
+}
+
+// Matches reports whether the query is the full timestamp, or a date, month or year prefix of it.
+func (t Timestamp) Matches(query string) bool {
+	return slices.Contains(t.Terms(), query)
+}
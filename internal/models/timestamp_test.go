@@ -0,0 +1,94 @@
+package models_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestTimestampRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		json string
+	}{
+		{name: "negative_offset", json: `"2016-04-28T11:19:34 -10:00"`},
+		{name: "positive_offset", json: `"2016-04-28T11:19:34 +09:30"`},
+		{name: "empty", json: `""`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var ts models.Timestamp
+			assert.NilError(t, json.Unmarshal([]byte(tc.json), &ts))
+			buf, err := json.Marshal(ts)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.json, string(buf))
+		})
+	}
+}
+
+func TestTimestampInvalid(t *testing.T) {
+	t.Parallel()
+
+	var ts models.Timestamp
+	assert.ErrorContains(t, json.Unmarshal([]byte(`"2016-04-28T11:19:34-10:00"`), &ts), "invalid timestamp")
+}
+
+func TestTimestampMatches(t *testing.T) {
+	t.Parallel()
+
+	ts := models.MustParseTimestamp("2016-04-28T11:19:34 -10:00")
+	for _, tc := range []struct {
+		query    string
+		expected bool
+	}{
+		{query: "2016-04-28T11:19:34 -10:00", expected: true},
+		{query: "2016-04-28", expected: true},
+		{query: "2016-04", expected: true},
+		{query: "2016", expected: true},
+		{query: "2016-04-29", expected: false},
+		{query: "2016-4", expected: false},
+		{query: "", expected: false},
+	} {
+		tc := tc
+		t.Run(tc.query, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, ts.Matches(tc.query))
+		})
+	}
+
+	assert.Assert(t, models.Timestamp{}.Matches(""))
+}
+
+func TestTimestampFormatIn(t *testing.T) {
+	t.Parallel()
+
+	ts := models.MustParseTimestamp("2016-04-28T11:19:34 -10:00")
+	assert.Equal(t, "2016-04-28T11:19:34 -10:00", ts.FormatIn(nil))
+	assert.Equal(t, "2016-04-28T21:19:34 +00:00", ts.FormatIn(time.UTC))
+	assert.Equal(t, "", models.Timestamp{}.FormatIn(time.UTC))
+}
+
+func TestSetLocation(t *testing.T) {
+	t.Parallel()
+
+	ticket := &models.Ticket{
+		CreatedAt: models.MustParseTimestamp("2016-04-28T21:19:34 -10:00"),
+	}
+	models.SetLocation(ticket, time.FixedZone("AEST", 10*60*60))
+
+	// the same instant, the next day in the location
+	assert.Assert(t, ticket.CreatedAt.Equal(models.MustParseTimestamp("2016-04-28T21:19:34 -10:00").Time))
+	assert.Assert(t, ticket.CreatedAt.Matches("2016-04-29"))
+	assert.Assert(t, !ticket.CreatedAt.Matches("2016-04-28"))
+	assert.Equal(t, "2016-04-29T17:19:34 +10:00", ticket.CreatedAt.String())
+	// missing timestamps stay missing
+	assert.Assert(t, ticket.DueAt.IsZero())
+}
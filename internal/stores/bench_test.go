@@ -0,0 +1,158 @@
+package stores_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"gotest.tools/v3/assert"
+)
+
+const benchDataDir = "../../data"
+
+func BenchmarkHashStoreSearchScan(b *testing.B) {
+	//nolint:staticcheck
+	store, err := implementations.NewHashStore(benchDataDir)
+	assert.NilError(b, err)
+
+	for _, bc := range []struct {
+		docType, field, query string
+	}{
+		{docType: "Tickets", field: "status", query: "pending"},
+		{docType: "Users", field: "timezone", query: "Antigua"},
+		{docType: "Organizations", field: "details", query: "MegaCorp"},
+	} {
+		bc := bc
+		b.Run(bc.docType+"_"+bc.field, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := store.Search(bc.docType, bc.field, bc.query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNewInvertedStore(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := implementations.NewInvertedStore(benchDataDir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+var benchExportSize = flag.Int64("bench-export-size", 64<<20,
+	"size in bytes of the tickets.json generated for BenchmarkLoadLargeExport, e.g. 4294967296 for 4GiB")
+
+// writeLargeExport writes a data directory with the organizations and users in benchDataDir,
+// and at least size bytes of tickets, which are copies of the tickets in benchDataDir with new _ids.
+func writeLargeExport(b *testing.B, size int64) string {
+	b.Helper()
+
+	dir := b.TempDir()
+	for _, name := range []string{"organizations.json", "users.json"} {
+		buf, err := os.ReadFile(filepath.Join(benchDataDir, name))
+		assert.NilError(b, err)
+		assert.NilError(b, os.WriteFile(filepath.Join(dir, name), buf, 0o600))
+	}
+
+	buf, err := os.ReadFile(filepath.Join(benchDataDir, "tickets.json"))
+	assert.NilError(b, err)
+	tickets := []json.RawMessage{}
+	assert.NilError(b, json.Unmarshal(buf, &tickets))
+	ids := make([][]byte, 0, len(tickets))
+	for _, ticket := range tickets {
+		var id struct {
+			ID string `json:"_id"`
+		}
+		assert.NilError(b, json.Unmarshal(ticket, &id))
+		ids = append(ids, []byte(id.ID))
+	}
+
+	f, err := os.Create(filepath.Join(dir, "tickets.json"))
+	assert.NilError(b, err)
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	written, _ := w.WriteString("[\n")
+	for i := 0; int64(written) < size; i++ {
+		if i > 0 {
+			n, _ := w.WriteString(",\n")
+			written += n
+		}
+		ticket := tickets[i%len(tickets)]
+		n, err := w.Write(bytes.Replace(ticket, ids[i%len(tickets)], []byte(uuid.NewString()), 1))
+		assert.NilError(b, err)
+		written += n
+	}
+	_, err = w.WriteString("\n]\n")
+	assert.NilError(b, err)
+	assert.NilError(b, w.Flush())
+	return dir
+}
+
+// BenchmarkLoadLargeExport loads a generated export of -bench-export-size bytes, and reports the peak heap while loading
+// and the heap the store keeps afterwards, which the streaming loader keeps close together.
+func BenchmarkLoadLargeExport(b *testing.B) {
+	dir := writeLargeExport(b, *benchExportSize)
+	b.SetBytes(*benchExportSize)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		peak := samplePeakHeap()
+		store, err := implementations.NewInvertedStore(dir)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StopTimer()
+		b.ReportMetric(float64(peak())/(1<<20), "peak-heap-MiB")
+		runtime.GC()
+		b.ReportMetric(float64(heapObjects())/(1<<20), "store-heap-MiB")
+		runtime.KeepAlive(store)
+		b.StartTimer()
+	}
+}
+
+// heapObjects returns the bytes of the objects in the heap, reachable or not yet swept.
+func heapObjects() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+// samplePeakHeap samples the heap every few milliseconds until the returned function is called,
+// which returns the largest sample.
+func samplePeakHeap() func() uint64 {
+	done := make(chan struct{})
+	peak := make(chan uint64)
+	go func() {
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		largest := uint64(0)
+		for {
+			largest = max(largest, heapObjects())
+			select {
+			case <-done:
+				peak <- largest
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() uint64 {
+		close(done)
+		return <-peak
+	}
+}
@@ -0,0 +1,313 @@
+// Package cache remembers the results of the searches and traversals of a store.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+)
+
+// DefaultMaxBytes is the default bound of the memory used by the results a Store remembers.
+const DefaultMaxBytes = 32 << 20
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithMaxBytes bounds the memory used by the results a Store remembers, after which the least recently used are forgotten.
+func WithMaxBytes(maxBytes int64) Option {
+	return func(s *Store) {
+		s.maxBytes = maxBytes
+	}
+}
+
+// Stats counts how the results of a Store were found.
+type Stats struct {
+	// Hits and Misses are the number of calls that were and were not answered from the cache.
+	Hits, Misses uint64
+	// Evictions is the number of results forgotten to keep within the memory bound.
+	Evictions uint64
+	// Entries is the number of results remembered, and Bytes is an estimate of the memory they use.
+	Entries int
+	Bytes   int64
+}
+
+// Store is a stores.Store that remembers the results of searches, pages of searches, and traversals of another,
+// forgetting the least recently used once they use more memory than the bound.
+// The bound counts the memory of the results themselves, the documents in them are shared with the store.
+//
+// The results are forgotten when the documents change through Upsert or Delete,
+// or, if the store is a stores.Versioned, such as a reload.Store, when its version changes.
+// Results are shared by the calls that return them, and must not be changed.
+type Store struct {
+	store    stores.Store
+	maxBytes int64
+
+	mu sync.Mutex
+	// entries holds the results from the most to least recently used, indexed by their key in keys.
+	entries *list.List
+	keys    map[string]*list.Element
+	// version is the version of the store the results are from.
+	version uint64
+	// epoch counts the times the results were forgotten, so a result found before then is not remembered.
+	epoch uint64
+	stats Stats
+}
+
+type entry struct {
+	key   string
+	value any
+	bytes int64
+}
+
+// New returns a Store that remembers the results of store.
+func New(store stores.Store, opts ...Option) *Store {
+	s := &Store{
+		store:    store,
+		maxBytes: DefaultMaxBytes,
+		entries:  list.New(),
+		keys:     map[string]*list.Element{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.version = s.storeVersion()
+	return s
+}
+
+// Stats returns the statistics of the cache so far.
+func (s *Store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+// Purge forgets every result.
+func (s *Store) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purge()
+}
+
+func (s *Store) purge() {
+	s.entries.Init()
+	clear(s.keys)
+	s.stats.Entries, s.stats.Bytes = 0, 0
+	s.epoch++
+}
+
+// storeVersion returns the version of the store, or 0 if it is not versioned.
+func (s *Store) storeVersion() uint64 {
+	if versioned, ok := s.store.(stores.Versioned); ok {
+		return versioned.Version()
+	}
+	return 0
+}
+
+// get returns the result remembered for key, if it is from the current version of the store,
+// or the epoch in which the result found on a miss can be remembered.
+func (s *Store) get(key string) (any, uint64, bool) {
+	version := s.storeVersion()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if version != s.version {
+		s.purge()
+		s.version = version
+	}
+	if el, ok := s.keys[key]; ok {
+		s.stats.Hits++
+		s.entries.MoveToFront(el)
+		return el.Value.(*entry).value, s.epoch, true
+	}
+	s.stats.Misses++
+	return nil, s.epoch, false
+}
+
+// put remembers the result for key, unless the results have been forgotten since the epoch it was found in,
+// or the store has changed since.
+func (s *Store) put(key string, value any, bytes int64, epoch uint64) {
+	bytes += int64(len(key)) + entryBytes
+	if bytes > s.maxBytes {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if epoch != s.epoch || s.storeVersion() != s.version {
+		return
+	}
+	if el, ok := s.keys[key]; ok {
+		// another call found the same result at the same time.
+		s.entries.MoveToFront(el)
+		return
+	}
+	s.keys[key] = s.entries.PushFront(&entry{key: key, value: value, bytes: bytes})
+	s.stats.Entries++
+	s.stats.Bytes += bytes
+	for s.stats.Bytes > s.maxBytes {
+		oldest := s.entries.Remove(s.entries.Back()).(*entry)
+		delete(s.keys, oldest.key)
+		s.stats.Entries--
+		s.stats.Bytes -= oldest.bytes
+		s.stats.Evictions++
+	}
+}
+
+// cached returns the result remembered for key, or finds it with find and remembers it.
+// Errors are not remembered.
+func cached[T any](s *Store, key string, size func(T) int64, find func() (T, error)) (T, error) {
+	value, epoch, ok := s.get(key)
+	if ok {
+		return value.(T), nil
+	}
+	found, err := find()
+	if err != nil {
+		return found, err
+	}
+	s.put(key, found, size(found), epoch)
+	return found, nil
+}
+
+// key joins the parts of a cache key, which cannot contain the separator.
+func key(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// docKey identifies a document, by its source too, as documents from different sources may have the same _id.
+func docKey(doc models.Model) string {
+	return models.SourceOf(doc) + "/" + doc.DocumentType() + "/" + doc.StringID()
+}
+
+func (s *Store) ListDocumentTypes() []string {
+	return s.store.ListDocumentTypes()
+}
+
+func (s *Store) ListFields() map[string][]string {
+	return s.store.ListFields()
+}
+
+func (s *Store) ListValues(documentType, field string) []string {
+	return s.store.ListValues(documentType, field)
+}
+
+func (s *Store) Search(documentType, field, query string) ([]stores.Hit, error) {
+	return s.SearchContext(context.Background(), documentType, field, query)
+}
+
+func (s *Store) SearchContext(ctx context.Context, documentType, field, query string) ([]stores.Hit, error) {
+	hits, err := cached(s, key("search", documentType, field, query), hitsBytes, func() ([]stores.Hit, error) {
+		return s.store.SearchContext(ctx, documentType, field, query)
+	})
+	return slices.Clone(hits), err
+}
+
+func (s *Store) SearchPage(
+	ctx context.Context,
+	documentType, field, query string,
+	page stores.PageRequest,
+) (stores.Page, error) {
+	facets := make([]string, 0, len(page.Facets))
+	for _, facet := range page.Facets {
+		facets = append(facets, facet.String())
+	}
+	k := key(
+		"page", documentType, field, query,
+		strconv.Itoa(page.Limit), strconv.Itoa(page.Offset), page.Cursor, page.Sort.String(), strings.Join(facets, ","),
+	)
+	found, err := cached(s, k, pageBytes, func() (stores.Page, error) {
+		return s.store.SearchPage(ctx, documentType, field, query, page)
+	})
+	found.Hits = slices.Clone(found.Hits)
+	found.Facets = slices.Clone(found.Facets)
+	return found, err
+}
+
+func (s *Store) Related(doc models.Model, path ...string) ([]models.Model, error) {
+	return s.RelatedContext(context.Background(), doc, path...)
+}
+
+func (s *Store) RelatedContext(ctx context.Context, doc models.Model, path ...string) ([]models.Model, error) {
+	k := key(append([]string{"related", docKey(doc)}, path...)...)
+	related, err := cached(s, k, modelsBytes, func() ([]models.Model, error) {
+		return s.store.RelatedContext(ctx, doc, path...)
+	})
+	return slices.Clone(related), err
+}
+
+func (s *Store) Walk(doc models.Model, depth int) ([]models.Model, error) {
+	return s.WalkContext(context.Background(), doc, depth)
+}
+
+func (s *Store) WalkContext(ctx context.Context, doc models.Model, depth int) ([]models.Model, error) {
+	walked, err := cached(s, key("walk", docKey(doc), strconv.Itoa(depth)), modelsBytes, func() ([]models.Model, error) {
+		return s.store.WalkContext(ctx, doc, depth)
+	})
+	return slices.Clone(walked), err
+}
+
+// Upsert upserts doc into the store, and forgets every result, as any of them may include doc or the documents related to it.
+func (s *Store) Upsert(doc models.Model) error {
+	defer s.Purge()
+	return s.store.Upsert(doc)
+}
+
+// Delete deletes the document from the store, and forgets every result, like Upsert.
+func (s *Store) Delete(documentType, id string) error {
+	defer s.Purge()
+	return s.store.Delete(documentType, id)
+}
+
+// Estimates of the memory used by results, not counting the documents in them, which are shared with the store.
+const (
+	entryBytes     = 128
+	sliceBytes     = 24
+	interfaceBytes = 16
+	stringBytes    = 16
+	hitBytes       = interfaceBytes + sliceBytes + 8
+	mapEntryBytes  = 48
+)
+
+func modelsBytes(docs []models.Model) int64 {
+	bytes := int64(sliceBytes + interfaceBytes*len(docs))
+	for _, doc := range docs {
+		if related, ok := doc.(*models.RelatedModel); ok {
+			bytes += int64(interfaceBytes + stringBytes + len(related.Relation))
+		}
+	}
+	return bytes
+}
+
+func hitsBytes(hits []stores.Hit) int64 {
+	bytes := int64(sliceBytes)
+	for _, hit := range hits {
+		bytes += hitBytes
+		for _, field := range hit.MatchedFields {
+			bytes += int64(stringBytes + len(field))
+		}
+		for relation, docs := range hit.Related {
+			bytes += int64(mapEntryBytes+len(relation)) + modelsBytes(docs)
+		}
+	}
+	return bytes
+}
+
+func pageBytes(page stores.Page) int64 {
+	bytes := hitsBytes(page.Hits) + int64(len(page.NextCursor))
+	for _, facet := range page.Facets {
+		bytes += int64(len(facet.Facet.String()))
+		for _, bucket := range facet.Buckets {
+			bytes += int64(stringBytes + 8 + len(bucket.Key))
+		}
+	}
+	return bytes
+}
@@ -0,0 +1,205 @@
+package cache_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/cache"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/satrap-illustrations/zs/internal/stores/reload"
+	"gotest.tools/v3/assert"
+)
+
+func newStore(t *testing.T) stores.Store {
+	t.Helper()
+
+	store, err := implementations.NewInvertedStore("../../../data")
+	assert.NilError(t, err)
+	return store
+}
+
+func organizationName(t *testing.T, s stores.Store, id string) string {
+	t.Helper()
+
+	doc, err := stores.Find(s, "Organizations", id)
+	assert.NilError(t, err)
+	organization, ok := doc.(*models.Organization)
+	assert.Assert(t, ok)
+	return organization.Name
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := cache.New(newStore(t))
+
+	hits, err := s.Search("Tickets", "organization_id", "118")
+	assert.NilError(t, err)
+	assert.Equal(t, 11, len(hits))
+	assert.DeepEqual(t, cache.Stats{Misses: 1, Entries: 1, Bytes: s.Stats().Bytes}, s.Stats())
+
+	again, err := s.SearchContext(ctx, "Tickets", "organization_id", "118")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stores.Documents(hits), stores.Documents(again))
+	assert.Equal(t, uint64(1), s.Stats().Hits)
+
+	// the results returned are copies
+	again[0] = stores.Hit{}
+	again, err = s.Search("Tickets", "organization_id", "118")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stores.Documents(hits), stores.Documents(again))
+
+	// a different page of the same search is a different result
+	page := stores.PageRequest{Limit: 5, Facets: []stores.Facet{{Field: "priority", Kind: stores.Terms}}}
+	first, err := s.SearchPage(ctx, "Tickets", "organization_id", "118", page)
+	assert.NilError(t, err)
+	page.Offset = 5
+	second, err := s.SearchPage(ctx, "Tickets", "organization_id", "118", page)
+	assert.NilError(t, err)
+	assert.Assert(t, first.Hits[0].Document != second.Hits[0].Document)
+	cached, err := s.SearchPage(ctx, "Tickets", "organization_id", "118", page)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stores.Documents(second.Hits), stores.Documents(cached.Hits))
+
+	// as are relations
+	organization := hits[0].Related["organization"][0]
+	related, err := s.Related(organization, "tickets")
+	assert.NilError(t, err)
+	assert.Equal(t, 11, len(related))
+	related, err = s.RelatedContext(ctx, organization, "tickets")
+	assert.NilError(t, err)
+	assert.Equal(t, 11, len(related))
+	walked, err := s.Walk(organization, 1)
+	assert.NilError(t, err)
+	assert.Assert(t, len(walked) > 0)
+
+	stats := s.Stats()
+	assert.Equal(t, uint64(4), stats.Hits)
+	assert.Equal(t, uint64(5), stats.Misses)
+	assert.Equal(t, 5, stats.Entries)
+
+	// errors are not remembered
+	_, err = s.Search("Tickets", "missing", "118")
+	assert.ErrorIs(t, err, doctype.ErrInvalidField)
+	assert.Equal(t, 5, s.Stats().Entries)
+}
+
+func TestCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	s := cache.New(newStore(t), cache.WithMaxBytes(2048))
+	for _, id := range []string{"101", "102", "103", "104", "105", "106"} {
+		_, err := s.Search("Organizations", "_id", id)
+		assert.NilError(t, err)
+	}
+	stats := s.Stats()
+	assert.Assert(t, stats.Evictions > 0)
+	assert.Assert(t, stats.Bytes <= 2048)
+	assert.Equal(t, 6-int(stats.Evictions), stats.Entries)
+
+	// the most recently used is remembered, and the least forgotten
+	_, err := s.Search("Organizations", "_id", "106")
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(1), s.Stats().Hits)
+	_, err = s.Search("Organizations", "_id", "101")
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(1), s.Stats().Hits)
+
+	// results larger than the bound are not remembered
+	tiny := cache.New(newStore(t), cache.WithMaxBytes(1))
+	_, err = tiny.Search("Organizations", "_id", "101")
+	assert.NilError(t, err)
+	assert.Equal(t, 0, tiny.Stats().Entries)
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		mutate func(t *testing.T, s *cache.Store, underlying stores.Store)
+	}{
+		{
+			name: "upsert through the cache",
+			mutate: func(t *testing.T, s *cache.Store, _ stores.Store) {
+				t.Helper()
+				assert.NilError(t, s.Upsert(&models.Organization{ID: 101, Name: "Enthazed"}))
+			},
+		},
+		{
+			name: "upsert to the underlying store",
+			mutate: func(t *testing.T, _ *cache.Store, underlying stores.Store) {
+				t.Helper()
+				assert.NilError(t, underlying.Upsert(&models.Organization{ID: 101, Name: "Enthazed"}))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			underlying := newStore(t)
+			s := cache.New(underlying)
+			assert.Equal(t, "Enthaze", organizationName(t, s, "101"))
+			assert.Equal(t, "Enthaze", organizationName(t, s, "101"))
+			assert.Equal(t, uint64(1), s.Stats().Hits)
+
+			tc.mutate(t, s, underlying)
+			assert.Equal(t, "Enthazed", organizationName(t, s, "101"))
+		})
+	}
+
+	t.Run("delete through the cache", func(t *testing.T) {
+		t.Parallel()
+
+		s := cache.New(newStore(t))
+		assert.Equal(t, "Enthaze", organizationName(t, s, "101"))
+		assert.NilError(t, s.Delete("Organizations", "101"))
+		_, err := stores.Find(s, "Organizations", "101")
+		assert.ErrorIs(t, err, stores.ErrDocumentNotFound)
+	})
+}
+
+func TestCacheReload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"organizations.json", "tickets.json", "users.json"} {
+		buf, err := os.ReadFile(filepath.Join("../../../data", name))
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), buf, 0o600))
+	}
+	load := func() (stores.Store, error) { return implementations.NewInvertedStore(dir) }
+	initial, err := load()
+	assert.NilError(t, err)
+	watched, err := reload.Watch(dir, initial, load, reload.WithDelay(100*time.Millisecond))
+	assert.NilError(t, err)
+	defer watched.Close()
+
+	s := cache.New(watched)
+	assert.Equal(t, "Enthaze", organizationName(t, s, "101"))
+
+	path := filepath.Join(dir, "organizations.json")
+	buf, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(path+".tmp", []byte(strings.ReplaceAll(string(buf), `"Enthaze"`, `"Enthazed"`)), 0o600))
+	assert.NilError(t, os.Rename(path+".tmp", path))
+	select {
+	case e := <-watched.Events():
+		assert.NilError(t, e.Err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the store was not reloaded")
+	}
+
+	assert.Equal(t, "Enthazed", organizationName(t, s, "101"))
+}
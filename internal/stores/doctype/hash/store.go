@@ -0,0 +1,159 @@
+package hash
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype"
+	"github.com/satrap-illustrations/zs/internal/tokeniser"
+)
+
+// Store is a hash map of documents by _id. Searching any other field scans the documents.
+type Store[ID comparable, T models.Model] struct {
+	docs    map[ID]T
+	id      func(T) ID
+	parseID func(string) (ID, error)
+}
+
+// New stores docs by the _id returned by id. Queries of the _id field are parsed with parseID.
+func New[ID comparable, T models.Model](docs []T, id func(T) ID, parseID func(string) (ID, error)) Store[ID, T] {
+	s := Store[ID, T]{
+		docs:    make(map[ID]T, len(docs)),
+		id:      id,
+		parseID: parseID,
+	}
+	for _, doc := range docs {
+		s.docs[id(doc)] = doc
+	}
+	return s
+}
+
+func (s Store[ID, T]) Upsert(doc T) {
+	s.docs[s.id(doc)] = doc
+}
+
+func (s Store[ID, T]) Delete(id ID) (T, bool) {
+	doc, exists := s.docs[id]
+	delete(s.docs, id)
+	return doc, exists
+}
+
+func (s Store[ID, T]) ParseID(query string) (ID, error) {
+	return s.parseID(query)
+}
+
+func (s Store[ID, T]) ListFields() []string {
+	var zero T
+	extraFields := models.ExtraFieldSet{}
+	for _, doc := range s.docs {
+		extraFields.Add(doc)
+	}
+	return append(models.FieldSlice(zero), extraFields.Sorted()...)
+}
+
+func (s Store[ID, T]) Search(ctx context.Context, field, query string) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var zero T
+	i, exists := zero.Fields().Get(field)
+	if !exists {
+		return s.searchExtraField(ctx, field, query)
+	}
+
+	if field == "_id" {
+		id, err := s.parseID(query)
+		if err != nil {
+			return nil, err
+		}
+		if doc, ok := s.docs[id]; ok {
+			return []T{doc}, nil
+		}
+		return []T{}, nil
+	}
+
+	out := []T{}
+	scanned := 0
+	for _, doc := range s.docs {
+		if scanned++; scanned%doctype.CheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if models.ValueContains(doc.ValueAtIdx(i), query) {
+			out = append(out, doc)
+		}
+	}
+
+	return out, nil
+}
+
+// searchExtraField scans the extra fields of the documents at the dotted path field.
+// It returns doctype.ErrInvalidField if no document has the field.
+func (s Store[ID, T]) searchExtraField(ctx context.Context, field, query string) ([]T, error) {
+	found := false
+	out := []T{}
+	scanned := 0
+	for _, doc := range s.docs {
+		if scanned++; scanned%doctype.CheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		values, exists := doc.ExtraFields().Flatten()[field]
+		found = found || exists
+		for _, value := range values {
+			if models.ValueContains(value, query) {
+				out = append(out, doc)
+				break
+			}
+		}
+	}
+	if !found {
+		var zero T
+		return nil, fmt.Errorf("%w for %s: %s", doctype.ErrInvalidField, zero.DocumentType(), field)
+	}
+	return out, nil
+}
+
+// TermCounts tokenises the field of each document, as there is no index to count the terms in.
+func (s Store[ID, T]) TermCounts(ctx context.Context, field string, docs []T) (map[string]int, error) {
+	out := map[string]int{}
+	for i, doc := range docs {
+		if (i+1)%doctype.CheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		counted := map[string]bool{}
+		for _, token := range tokeniser.TokeniseField(doc, field) {
+			if !counted[token.Text] {
+				counted[token.Text] = true
+				out[token.Text]++
+			}
+		}
+	}
+	return out, nil
+}
+
+// Builder stores documents as they are added. The hash store has no index, so it does not matter when the computed fields are set.
+type Builder[ID comparable, T models.Model] struct {
+	store Store[ID, T]
+}
+
+// NewBuilder returns a Builder of an empty Store, see New.
+func NewBuilder[ID comparable, T models.Model](id func(T) ID, parseID func(string) (ID, error)) Builder[ID, T] {
+	return Builder[ID, T]{store: New[ID, T](nil, id, parseID)}
+}
+
+func (b Builder[ID, T]) Add(docs []T) {
+	for _, doc := range docs {
+		b.store.Upsert(doc)
+	}
+}
+
+func (b Builder[ID, T]) Build() doctype.Store[ID, T] {
+	return b.store
+}
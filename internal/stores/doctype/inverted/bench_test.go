@@ -0,0 +1,151 @@
+package inverted_test
+
+import (
+	"context"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype/inverted"
+	"github.com/satrap-illustrations/zs/internal/tokeniser"
+	"gotest.tools/v3/assert"
+)
+
+// benchCopies is how many copies of each ticket in the data the benchmarks index.
+const benchCopies = 100
+
+// benchTickets returns copies of the tickets in the data with new _ids.
+func benchTickets(b *testing.B) []*models.Ticket {
+	b.Helper()
+
+	tickets := readTickets(b)
+	out := make([]*models.Ticket, 0, len(tickets)*benchCopies)
+	for i := 0; i < benchCopies; i++ {
+		for _, ticket := range tickets {
+			copied := *ticket
+			copied.ID = uuid.New()
+			out = append(out, &copied)
+		}
+	}
+	return out
+}
+
+// sliceIndex is the index as it was before the postings were compressed, the _id of the documents with each token,
+// once for each time the token is in them. It is the baseline the benchmarks compare the Store to.
+type sliceIndex struct {
+	models map[uuid.UUID]*models.Ticket
+	index  map[tokeniser.Token][]uuid.UUID
+}
+
+func newSliceIndex(tickets []*models.Ticket) sliceIndex {
+	s := sliceIndex{models: map[uuid.UUID]*models.Ticket{}, index: map[tokeniser.Token][]uuid.UUID{}}
+	for _, ticket := range tickets {
+		s.models[ticket.ID] = ticket
+		for _, token := range tokeniser.Tokenise(ticket) {
+			s.index[token] = append(s.index[token], ticket.ID)
+		}
+	}
+	return s
+}
+
+// search finds the documents with every word of the query, by filtering the postings of the first word
+// by a set of the postings of each of the others.
+func (s sliceIndex) search(field, query string) []*models.Ticket {
+	tokens := tokeniser.TokeniseQuery(field, query)
+	ids := s.index[tokens[0]]
+	for _, token := range tokens[1:] {
+		set := make(map[uuid.UUID]struct{}, len(s.index[token]))
+		for _, id := range s.index[token] {
+			set[id] = struct{}{}
+		}
+		filtered := []uuid.UUID{}
+		for _, id := range ids {
+			if _, ok := set[id]; ok {
+				filtered = append(filtered, id)
+			}
+		}
+		ids = filtered
+	}
+	out := make([]*models.Ticket, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, s.models[id])
+	}
+	return out
+}
+
+// heapObjects returns the bytes of the objects in the heap after a collection.
+func heapObjects() uint64 {
+	runtime.GC()
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+// BenchmarkIndexMemory reports the heap kept by the index of the tickets, and the time to build it,
+// for the compressed postings and the baseline.
+func BenchmarkIndexMemory(b *testing.B) {
+	tickets := benchTickets(b)
+	for _, bc := range []struct {
+		name  string
+		build func() any
+	}{
+		{name: "postings", build: func() any { return inverted.New(tickets, ticketID, uuid.Parse) }},
+		{name: "slices", build: func() any { return newSliceIndex(tickets) }},
+	} {
+		bc := bc
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				before := heapObjects()
+				b.StartTimer()
+				index := bc.build()
+				b.StopTimer()
+				b.ReportMetric(float64(heapObjects()-before)/float64(len(tickets)), "heap-B/doc")
+				runtime.KeepAlive(index)
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+// BenchmarkSearch compares searches of one word, and intersections of several, for the compressed postings and the baseline.
+func BenchmarkSearch(b *testing.B) {
+	tickets := benchTickets(b)
+	s := inverted.New(tickets, ticketID, uuid.Parse)
+	baseline := newSliceIndex(tickets)
+	ctx := context.Background()
+
+	for _, bc := range []struct {
+		name, field, query string
+	}{
+		{name: "common", field: "status", query: "open"},
+		{name: "rare", field: "tags", query: "Ohio"},
+		{name: "common_and_common", field: "subject", query: "A Nuisance"},
+		{name: "rare_and_common", field: "subject", query: "Nuisance Kiribati"},
+		{name: "three", field: "description", query: "Lorem ipsum est"},
+	} {
+		bc := bc
+		expected := baseline.search(bc.field, bc.query)
+		found, err := s.Search(ctx, bc.field, bc.query)
+		assert.NilError(b, err)
+		assert.Assert(b, len(found) > 0 && len(found) <= len(expected), bc.name)
+
+		b.Run(bc.name+"/postings", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := s.Search(ctx, bc.field, bc.query); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(bc.name+"/slices", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = baseline.search(bc.field, bc.query)
+			}
+		})
+	}
+}
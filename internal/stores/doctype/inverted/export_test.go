@@ -0,0 +1,85 @@
+package inverted
+
+import (
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/tokeniser"
+)
+
+// This file exports the internals of the package to its tests, which are in the inverted_test package.
+
+// Postings is postings, with the methods the tests use exported.
+type Postings struct {
+	p *postings
+}
+
+func NewPostings(ordinals []uint32) Postings {
+	return Postings{p: newPostings(ordinals)}
+}
+
+func (p Postings) Len() int {
+	return p.p.len
+}
+
+func (p Postings) Add(ordinal uint32) {
+	p.p.add(ordinal)
+}
+
+func (p Postings) Remove(ordinal uint32) bool {
+	return p.p.remove(ordinal)
+}
+
+func (p Postings) Ordinals() []uint32 {
+	return p.p.ordinals(nil)
+}
+
+func (p Postings) Cursor() Cursor {
+	return Cursor{c: p.p.cursor()}
+}
+
+// Cursor is cursor, with its methods exported.
+type Cursor struct {
+	c *cursor
+}
+
+func (c Cursor) Next() (uint32, bool) {
+	return c.c.next()
+}
+
+func (c Cursor) Seek(x uint32) (uint32, bool) {
+	return c.c.seek(x)
+}
+
+var Gallop = gallop
+
+func Intersect(lists []Postings) []uint32 {
+	ps := make([]*postings, 0, len(lists))
+	for _, p := range lists {
+		ps = append(ps, p.p)
+	}
+	return intersect(ps)
+}
+
+// Internals are the documents, numbering and index of a Store, to compare stores built in different ways.
+type Internals[ID comparable, T models.Model] struct {
+	Models      map[ID]T
+	ExtraFields models.ExtraFieldSet
+	// IDs are the _id numbered by each ordinal, and Ordinals the ordinal of each document still stored.
+	IDs      []ID
+	Ordinals map[ID]uint32
+	// Postings are the ordinals in each posting list of the index.
+	Postings map[tokeniser.Token][]uint32
+}
+
+func InternalsOf[ID comparable, T models.Model](s Store[ID, T]) Internals[ID, T] {
+	postings := map[tokeniser.Token][]uint32{}
+	for token, p := range s.index {
+		postings[token] = p.ordinals(nil)
+	}
+	return Internals[ID, T]{
+		Models:      s.models,
+		ExtraFields: s.extraFields,
+		IDs:         s.numbering.ids,
+		Ordinals:    s.numbering.ordinals,
+		Postings:    postings,
+	}
+}
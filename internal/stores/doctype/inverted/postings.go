@@ -0,0 +1,199 @@
+package inverted
+
+import (
+	"encoding/binary"
+	"slices"
+)
+
+// blockSize is the number of ordinals in each block of postings, which can be skipped without decoding them.
+const blockSize = 128
+
+// postings is the set of ordinals of the documents with a token, in increasing order.
+// The ordinals are in blocks of blockSize, each the first ordinal followed by the uvarint differences between
+// successive ordinals, which are mostly a byte each when the documents are numbered densely.
+type postings struct {
+	// firsts are the first ordinal of each block, and offsets where the differences of the block start in deltas.
+	firsts  []uint32
+	offsets []uint32
+	deltas  []byte
+	// len is the number of ordinals, and last is the greatest, which the next is appended after.
+	len  int
+	last uint32
+}
+
+// newPostings encodes ordinals, which are in increasing order.
+func newPostings(ordinals []uint32) *postings {
+	p := &postings{}
+	for _, ordinal := range ordinals {
+		p.append(ordinal)
+	}
+	return p
+}
+
+// append adds an ordinal greater than the last.
+func (p *postings) append(ordinal uint32) {
+	if p.len%blockSize == 0 {
+		p.firsts = append(p.firsts, ordinal)
+		p.offsets = append(p.offsets, uint32(len(p.deltas)))
+	} else {
+		p.deltas = binary.AppendUvarint(p.deltas, uint64(ordinal-p.last))
+	}
+	p.len++
+	p.last = ordinal
+}
+
+// add adds an ordinal, which is usually greater than the last, as documents are numbered in the order they are added.
+func (p *postings) add(ordinal uint32) {
+	switch {
+	case p.len == 0 || ordinal > p.last:
+		p.append(ordinal)
+	case ordinal == p.last:
+		// a document is posted once, however many times the token is in it.
+	default:
+		ordinals := p.ordinals(nil)
+		if i, found := slices.BinarySearch(ordinals, ordinal); !found {
+			*p = *newPostings(slices.Insert(ordinals, i, ordinal))
+		}
+	}
+}
+
+// remove removes an ordinal, and reports whether it was there.
+func (p *postings) remove(ordinal uint32) bool {
+	ordinals := p.ordinals(nil)
+	i, found := slices.BinarySearch(ordinals, ordinal)
+	if found {
+		*p = *newPostings(slices.Delete(ordinals, i, i+1))
+	}
+	return found
+}
+
+// ordinals appends the ordinals to dst.
+func (p *postings) ordinals(dst []uint32) []uint32 {
+	dst = slices.Grow(dst, p.len)
+	c := p.cursor()
+	for ordinal, ok := c.next(); ok; ordinal, ok = c.next() {
+		dst = append(dst, ordinal)
+	}
+	return dst
+}
+
+func (p *postings) cursor() *cursor {
+	return &cursor{p: p, at: -1}
+}
+
+// cursor reads the ordinals of postings in order.
+type cursor struct {
+	p *postings
+	// at is the index of the current ordinal, -1 before the first, and pos is the offset of the difference after it.
+	at      int
+	pos     int
+	ordinal uint32
+}
+
+// next moves to the next ordinal, and returns it, or false after the last.
+func (c *cursor) next() (uint32, bool) {
+	if c.at+1 >= c.p.len {
+		c.at = c.p.len
+		return 0, false
+	}
+	c.at++
+	if c.at%blockSize == 0 {
+		block := c.at / blockSize
+		c.ordinal, c.pos = c.p.firsts[block], int(c.p.offsets[block])
+	} else {
+		delta, n := binary.Uvarint(c.p.deltas[c.pos:])
+		c.ordinal += uint32(delta)
+		c.pos += n
+	}
+	return c.ordinal, true
+}
+
+// seek moves to the first ordinal that is not less than x, from the current ordinal on, and returns it,
+// or false if there is none. It gallops over the first ordinals of the blocks after the current one,
+// so the blocks before x are skipped without decoding them.
+func (c *cursor) seek(x uint32) (uint32, bool) {
+	if c.at >= c.p.len {
+		return 0, false
+	}
+	if c.at >= 0 && c.ordinal >= x {
+		return c.ordinal, true
+	}
+
+	current := -1
+	if c.at >= 0 {
+		current = c.at / blockSize
+	}
+	after := c.p.firsts[current+1:]
+	rest := gallop(after, x)
+	// the last block that starts at or before x
+	block := current + len(after) - len(rest)
+	if len(rest) > 0 && rest[0] == x {
+		block++
+	}
+	if block > current {
+		c.at = block*blockSize - 1
+	}
+
+	for ordinal, ok := c.next(); ok; ordinal, ok = c.next() {
+		if ordinal >= x {
+			return ordinal, true
+		}
+	}
+	return 0, false
+}
+
+// gallop returns the suffix of sorted from the first value that is not less than x.
+// It probes ahead in doubling steps before searching between the last two probes,
+// so it takes time in the log of how far it moves rather than of the length of sorted.
+func gallop(sorted []uint32, x uint32) []uint32 {
+	if len(sorted) == 0 || sorted[0] >= x {
+		return sorted
+	}
+	// sorted[lo] < x, and x <= sorted[lo+step] if it is in sorted.
+	lo, step := 0, 1
+	for lo+step < len(sorted) && sorted[lo+step] < x {
+		lo += step
+		step *= 2
+	}
+	hi := min(lo+step, len(sorted))
+	i, _ := slices.BinarySearch(sorted[lo+1:hi], x)
+	return sorted[lo+1+i:]
+}
+
+// intersect returns the ordinals in every one of lists, in increasing order.
+// It reads the shortest, and seeks each of the others to its ordinals in turn,
+// so the time taken depends on the length of the shortest more than that of the others.
+func intersect(lists []*postings) []uint32 {
+	if len(lists) == 0 {
+		return []uint32{}
+	}
+	lists = slices.Clone(lists)
+	slices.SortFunc(lists, func(a, b *postings) int { return a.len - b.len })
+	cursors := make([]*cursor, len(lists))
+	for i, p := range lists {
+		cursors[i] = p.cursor()
+	}
+
+	out := []uint32{}
+	x, ok := cursors[0].next()
+	for ok {
+		matched := true
+		for _, c := range cursors[1:] {
+			y, found := c.seek(x)
+			if !found {
+				return out
+			}
+			if y > x {
+				x, matched = y, false
+				break
+			}
+		}
+		if matched {
+			out = append(out, x)
+			x, ok = cursors[0].next()
+		} else {
+			x, ok = cursors[0].seek(x)
+		}
+	}
+	return out
+}
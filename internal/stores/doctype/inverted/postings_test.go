@@ -0,0 +1,138 @@
+package inverted_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/satrap-illustrations/zs/internal/stores/doctype/inverted"
+	"gotest.tools/v3/assert"
+)
+
+// randomOrdinals returns n distinct ordinals less than max, in increasing order.
+func randomOrdinals(r *rand.Rand, n, max int) []uint32 {
+	set := map[uint32]bool{}
+	for len(set) < n {
+		set[uint32(r.Intn(max))] = true
+	}
+	out := make([]uint32, 0, n)
+	for ordinal := range set {
+		out = append(out, ordinal)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestPostings(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	ordinals := randomOrdinals(r, 1000, 1<<20)
+	p := inverted.NewPostings(ordinals)
+	assert.Equal(t, len(ordinals), p.Len())
+	assert.DeepEqual(t, ordinals, p.Ordinals())
+
+	// adding the last again, or one that is there, changes nothing
+	p.Add(ordinals[len(ordinals)-1])
+	p.Add(ordinals[500])
+	assert.DeepEqual(t, ordinals, p.Ordinals())
+
+	// and one that is not is inserted in order
+	ordinals = slices.Insert(ordinals, 500, ordinals[500]-1)
+	p.Add(ordinals[500])
+	assert.DeepEqual(t, ordinals, p.Ordinals())
+
+	assert.Assert(t, p.Remove(ordinals[0]))
+	assert.Assert(t, !p.Remove(ordinals[0]))
+	assert.DeepEqual(t, ordinals[1:], p.Ordinals())
+}
+
+func TestCursorSeek(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(2))
+	ordinals := randomOrdinals(r, 5000, 100000)
+	c := inverted.NewPostings(ordinals).Cursor()
+	x := uint32(0)
+	for {
+		got, ok := c.Seek(x)
+		i, _ := slices.BinarySearch(ordinals, x)
+		if i == len(ordinals) {
+			assert.Assert(t, !ok)
+			break
+		}
+		assert.Assert(t, ok)
+		assert.Equal(t, ordinals[i], got)
+		// seeking to before the current ordinal stays at it
+		got, _ = c.Seek(x / 2)
+		assert.Equal(t, ordinals[i], got)
+		x += uint32(r.Intn(500))
+	}
+	_, ok := c.Next()
+	assert.Assert(t, !ok)
+}
+
+func TestIntersect(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(3))
+	testCases := []struct {
+		name  string
+		sizes []int
+	}{
+		{name: "none", sizes: nil},
+		{name: "one", sizes: []int{300}},
+		{name: "equal", sizes: []int{2000, 2000}},
+		{name: "skewed", sizes: []int{10, 20000}},
+		{name: "several", sizes: []int{5000, 400, 9000}},
+		{name: "empty", sizes: []int{0, 1000}},
+	}
+
+	for _, tc := range testCases {
+		lists := make([]inverted.Postings, 0, len(tc.sizes))
+		expected := []uint32{}
+		for i, size := range tc.sizes {
+			ordinals := randomOrdinals(r, size, 40000)
+			lists = append(lists, inverted.NewPostings(ordinals))
+			if i == 0 {
+				expected = ordinals
+			} else {
+				expected = slices.DeleteFunc(expected, func(ordinal uint32) bool {
+					_, found := slices.BinarySearch(ordinals, ordinal)
+					return !found
+				})
+			}
+		}
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.DeepEqual(t, expected, inverted.Intersect(lists))
+		})
+	}
+}
+
+func TestGallop(t *testing.T) {
+	t.Parallel()
+
+	sorted := []uint32{2, 3, 5, 8, 13, 21, 34, 55, 89}
+	for _, tc := range []struct {
+		name     string
+		x        uint32
+		expected []uint32
+	}{
+		{name: "before_the_first", x: 0, expected: sorted},
+		{name: "the_first", x: 2, expected: sorted},
+		{name: "present", x: 21, expected: sorted[5:]},
+		{name: "absent", x: 22, expected: sorted[6:]},
+		{name: "the_last", x: 89, expected: sorted[8:]},
+		{name: "after_the_last", x: 90, expected: []uint32{}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.DeepEqual(t, tc.expected, inverted.Gallop(sorted, tc.x))
+		})
+	}
+	assert.Equal(t, 0, len(inverted.Gallop(nil, 1)))
+}
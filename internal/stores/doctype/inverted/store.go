@@ -0,0 +1,260 @@
+package inverted
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype"
+	"github.com/satrap-illustrations/zs/internal/tokeniser"
+)
+
+// Store is an inverted index of the tokens of each field to the documents with the token.
+// The documents are numbered densely in the order they are added, and the postings of a token are their ordinals.
+type Store[ID comparable, T models.Model] struct {
+	models    map[ID]T
+	id        func(T) ID
+	parseID   func(string) (ID, error)
+	numbering *numbering[ID]
+	// we aren't counting multiplicity at the moment, we could use it to rank the results.
+	index map[tokeniser.Token]*postings
+	// extraFields are the dotted paths of the fields in the data that are not declared in the model.
+	extraFields models.ExtraFieldSet
+}
+
+// numbering numbers the documents in the order they are added.
+// The ordinal of a deleted document is not used again until the documents are renumbered.
+type numbering[ID comparable] struct {
+	// ids are the _id of the document numbered by each ordinal, and ordinals the ordinal of each document still stored.
+	ids      []ID
+	ordinals map[ID]uint32
+}
+
+func (n *numbering[ID]) add(id ID) uint32 {
+	ordinal := uint32(len(n.ids))
+	n.ids = append(n.ids, id)
+	n.ordinals[id] = ordinal
+	return ordinal
+}
+
+// deleted is the number of ordinals of deleted documents.
+func (n *numbering[ID]) deleted() int {
+	return len(n.ids) - len(n.ordinals)
+}
+
+// New indexes docs by the _id returned by id. Queries of the _id field are parsed with parseID.
+func New[ID comparable, T models.Model](docs []T, id func(T) ID, parseID func(string) (ID, error)) Store[ID, T] {
+	s := Store[ID, T]{
+		models:  make(map[ID]T, len(docs)),
+		id:      id,
+		parseID: parseID,
+		numbering: &numbering[ID]{
+			ids:      make([]ID, 0, len(docs)),
+			ordinals: make(map[ID]uint32, len(docs)),
+		},
+		index:       map[tokeniser.Token]*postings{},
+		extraFields: models.ExtraFieldSet{},
+	}
+	// tokenising is most of the work, so it is spread over the CPUs, and only the index is built in order.
+	for i, tokens := range tokeniser.TokeniseAll(docs, runtime.GOMAXPROCS(0), tokeniser.Tokenise) {
+		s.add(docs[i], tokens)
+	}
+	return s
+}
+
+// add indexes doc by tokens. A document with the same _id as one added before replaces it, as if it were upserted.
+func (s Store[ID, T]) add(doc T, tokens []tokeniser.Token) {
+	id := s.id(doc)
+	s.Delete(id)
+	s.models[id] = doc
+	s.extraFields.Add(doc)
+	ordinal := s.numbering.add(id)
+	for _, token := range tokens {
+		s.post(token, ordinal)
+	}
+}
+
+func (s Store[ID, T]) post(token tokeniser.Token, ordinal uint32) {
+	p, exists := s.index[token]
+	if !exists {
+		p = &postings{}
+		s.index[token] = p
+	}
+	p.add(ordinal)
+}
+
+// TermCounts counts the docs in the postings of each token of the field in the index.
+func (s Store[ID, T]) TermCounts(ctx context.Context, field string, docs []T) (map[string]int, error) {
+	// matched has a bit for the ordinal of each doc.
+	matched := make([]uint64, (len(s.numbering.ids)+63)/64)
+	for _, doc := range docs {
+		if ordinal, ok := s.numbering.ordinals[s.id(doc)]; ok {
+			matched[ordinal/64] |= 1 << (ordinal % 64)
+		}
+	}
+
+	out := map[string]int{}
+	visited := 0
+	for token, p := range s.index {
+		if token.Field != field {
+			continue
+		}
+		c := p.cursor()
+		for ordinal, ok := c.next(); ok; ordinal, ok = c.next() {
+			if visited++; visited%doctype.CheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+			if matched[ordinal/64]&(1<<(ordinal%64)) != 0 {
+				out[token.Text]++
+			}
+		}
+	}
+	return out, nil
+}
+
+// Builder indexes documents as they are added, except for their computed fields, which are indexed by Build.
+type Builder[ID comparable, T models.Model] struct {
+	store Store[ID, T]
+}
+
+// NewBuilder returns a Builder of an empty Store, see New.
+func NewBuilder[ID comparable, T models.Model](id func(T) ID, parseID func(string) (ID, error)) *Builder[ID, T] {
+	return &Builder[ID, T]{store: New[ID, T](nil, id, parseID)}
+}
+
+// Add indexes the fields of docs that are read from the data, tokenising them in parallel like New.
+func (b *Builder[ID, T]) Add(docs []T) {
+	for i, tokens := range tokeniser.TokeniseAll(docs, runtime.GOMAXPROCS(0), tokeniser.TokeniseStored) {
+		b.store.add(docs[i], tokens)
+	}
+}
+
+// Build indexes the computed fields of the documents added, in the order they were numbered, and returns the store.
+func (b *Builder[ID, T]) Build() doctype.Store[ID, T] {
+	for ordinal, id := range b.store.numbering.ids {
+		// a document replaced by a later one with the same _id is no longer numbered by its ordinal.
+		if b.store.numbering.ordinals[id] != uint32(ordinal) {
+			continue
+		}
+		for _, token := range tokeniser.TokeniseComputed(b.store.models[id]) {
+			b.store.post(token, uint32(ordinal))
+		}
+	}
+	return b.store
+}
+
+// Upsert indexes doc, after removing the postings of the document it replaces.
+func (s Store[ID, T]) Upsert(doc T) {
+	s.add(doc, tokeniser.Tokenise(doc))
+}
+
+// Delete removes the document from the postings of its tokens, and the postings that are left empty.
+func (s Store[ID, T]) Delete(id ID) (T, bool) {
+	doc, exists := s.models[id]
+	if !exists {
+		return doc, false
+	}
+	ordinal := s.numbering.ordinals[id]
+	delete(s.models, id)
+	delete(s.numbering.ordinals, id)
+	s.extraFields.Remove(doc)
+	for _, token := range tokeniser.Tokenise(doc) {
+		if p, exists := s.index[token]; exists && p.remove(ordinal) && p.len == 0 {
+			delete(s.index, token)
+		}
+	}
+	if deleted := s.numbering.deleted(); deleted > blockSize && deleted > len(s.numbering.ordinals) {
+		s.renumber()
+	}
+	return doc, true
+}
+
+// renumber numbers the documents densely again, once most ordinals are of deleted documents,
+// keeping their order so the postings stay in order.
+func (s Store[ID, T]) renumber() {
+	renumbered := make([]uint32, len(s.numbering.ids))
+	ids := make([]ID, 0, len(s.numbering.ordinals))
+	for ordinal, id := range s.numbering.ids {
+		if s.numbering.ordinals[id] == uint32(ordinal) {
+			renumbered[ordinal] = uint32(len(ids))
+			s.numbering.ordinals[id] = uint32(len(ids))
+			ids = append(ids, id)
+		}
+	}
+	s.numbering.ids = ids
+
+	var ordinals []uint32
+	for _, p := range s.index {
+		ordinals = p.ordinals(ordinals[:0])
+		for i, ordinal := range ordinals {
+			ordinals[i] = renumbered[ordinal]
+		}
+		*p = *newPostings(ordinals)
+	}
+}
+
+func (s Store[ID, T]) ParseID(query string) (ID, error) {
+	return s.parseID(query)
+}
+
+func (s Store[ID, T]) ListFields() []string {
+	var zero T
+	return append(models.FieldSlice(zero), s.extraFields.Sorted()...)
+}
+
+func (s Store[ID, T]) Search(ctx context.Context, field, query string) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var zero T
+	if _, exists := zero.Fields().Get(field); !exists && !s.extraFields.Has(field) {
+		return nil, fmt.Errorf("%w for %s: %s", doctype.ErrInvalidField, zero.DocumentType(), field)
+	}
+
+	if field == "_id" {
+		id, err := s.parseID(query)
+		if err != nil {
+			return nil, err
+		}
+		if doc, ok := s.models[id]; ok {
+			return []T{doc}, nil
+		}
+		return []T{}, nil
+	}
+
+	var ordinals []uint32
+	token := tokeniser.Token{
+		Text:  query,
+		Field: field,
+	}
+	if p, exists := s.index[token]; exists {
+		ordinals = p.ordinals(nil)
+	} else if tokens := tokeniser.TokeniseQuery(field, query); len(tokens) > 1 {
+		// a query of several words matches the documents with all of them.
+		lists := make([]*postings, 0, len(tokens))
+		for _, token := range tokens {
+			p, exists := s.index[token]
+			if !exists {
+				return []T{}, nil
+			}
+			lists = append(lists, p)
+		}
+		ordinals = intersect(lists)
+	}
+
+	out := make([]T, 0, len(ordinals))
+	for i, ordinal := range ordinals {
+		if (i+1)%doctype.CheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, s.models[s.numbering.ids[ordinal]])
+	}
+
+	return out, nil
+}
@@ -0,0 +1,165 @@
+package inverted_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype/inverted"
+	"gotest.tools/v3/assert"
+)
+
+func ticketID(t *models.Ticket) uuid.UUID { return t.ID }
+
+func readTickets(t testing.TB) []*models.Ticket {
+	t.Helper()
+
+	buf, err := os.ReadFile("../../../../data/tickets.json")
+	assert.NilError(t, err)
+	tickets := []*models.Ticket{}
+	assert.NilError(t, json.Unmarshal(buf, &tickets))
+	return tickets
+}
+
+// normalised returns the index with the _id of the documents in each posting list, sorted,
+// as the ordinals of the documents depend on the order they were added in.
+func normalised(in inverted.Internals[uuid.UUID, *models.Ticket]) map[string][]uuid.UUID {
+	out := map[string][]uuid.UUID{}
+	for token, ordinals := range in.Postings {
+		sorted := []uuid.UUID{}
+		for _, ordinal := range ordinals {
+			sorted = append(sorted, in.IDs[ordinal])
+		}
+		slices.SortFunc(sorted, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+		out[token.Field+":"+token.Text] = sorted
+	}
+	return out
+}
+
+func TestUpsertAndDeleteMatchFreshIndex(t *testing.T) {
+	t.Parallel()
+
+	tickets := readTickets(t)
+	s := inverted.New(tickets[:150], ticketID, uuid.Parse)
+
+	final := slices.Clone(tickets)
+
+	// add the rest of the tickets
+	for _, ticket := range tickets[150:] {
+		s.Upsert(ticket)
+	}
+
+	// update a ticket, changing declared and extra fields
+	updated := *tickets[0]
+	updated.Subject = "A Brand New Subject"
+	updated.Tags = []string{"Ohio", "Texas"}
+	updated.Status = models.TicketStatusSolved
+	updated.Extras = models.Extras{"custom_fields": map[string]any{"region": "EU"}}
+	s.Upsert(&updated)
+	final[0] = &updated
+
+	// and remove the extra field again from another
+	withExtras := *tickets[1]
+	withExtras.Extras = models.Extras{"custom_fields": map[string]any{"region": "US"}}
+	s.Upsert(&withExtras)
+	s.Upsert(tickets[1])
+
+	// delete some tickets
+	for _, ticket := range tickets[10:20] {
+		deleted, exists := s.Delete(ticket.ID)
+		assert.Assert(t, exists)
+		assert.Equal(t, ticket, deleted)
+	}
+	final = slices.Delete(final, 10, 20)
+
+	_, exists := s.Delete(tickets[10].ID)
+	assert.Assert(t, !exists)
+
+	fresh := inverted.InternalsOf(inverted.New(final, ticketID, uuid.Parse))
+	got := inverted.InternalsOf(s)
+	assert.DeepEqual(t, fresh.Models, got.Models)
+	assert.DeepEqual(t, fresh.ExtraFields, got.ExtraFields)
+	assert.DeepEqual(t, normalised(fresh), normalised(got))
+}
+
+func TestBuilderMatchesNew(t *testing.T) {
+	t.Parallel()
+
+	tickets := readTickets(t)
+	b := inverted.NewBuilder(ticketID, uuid.Parse)
+	for start := 0; start < len(tickets); start += 50 {
+		b.Add(tickets[start:min(start+50, len(tickets))])
+	}
+	// the computed fields are set once all the documents are read, before the store is built.
+	for i, ticket := range tickets {
+		ticket.Overdue = i%3 == 0
+	}
+	s, ok := b.Build().(inverted.Store[uuid.UUID, *models.Ticket])
+	assert.Assert(t, ok)
+
+	fresh := inverted.InternalsOf(inverted.New(tickets, ticketID, uuid.Parse))
+	got := inverted.InternalsOf(s)
+	assert.DeepEqual(t, fresh.Models, got.Models)
+	assert.DeepEqual(t, fresh.ExtraFields, got.ExtraFields)
+	assert.DeepEqual(t, fresh.IDs, got.IDs)
+	assert.DeepEqual(t, fresh.Ordinals, got.Ordinals)
+	assert.DeepEqual(t, fresh.Postings, got.Postings)
+}
+
+func TestDeleteRenumbers(t *testing.T) {
+	t.Parallel()
+
+	tickets := readTickets(t)
+	s := inverted.New(tickets, ticketID, uuid.Parse)
+
+	// deleting most of the documents numbers the rest densely again, in the same order.
+	kept := []*models.Ticket{}
+	for i, ticket := range tickets {
+		if i%4 == 0 {
+			kept = append(kept, ticket)
+			continue
+		}
+		_, exists := s.Delete(ticket.ID)
+		assert.Assert(t, exists)
+	}
+	got := inverted.InternalsOf(s)
+	assert.Assert(t, len(got.IDs) < len(tickets))
+	assert.Equal(t, len(kept), len(got.Ordinals))
+
+	fresh := inverted.New(kept, ticketID, uuid.Parse)
+	assert.DeepEqual(t, inverted.InternalsOf(fresh).Models, got.Models)
+	assert.DeepEqual(t, normalised(inverted.InternalsOf(fresh)), normalised(got))
+
+	found, err := s.Search(context.Background(), "status", "open")
+	assert.NilError(t, err)
+	expected, err := fresh.Search(context.Background(), "status", "open")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, expected, found)
+}
+
+func TestSearchWords(t *testing.T) {
+	t.Parallel()
+
+	s := inverted.New(readTickets(t), ticketID, uuid.Parse)
+	ctx := context.Background()
+
+	// the words of a query match the documents with all of them, in any order.
+	found, err := s.Search(ctx, "subject", "Nuisance Kiribati")
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(found))
+	ticket := found[0]
+	assert.Equal(t, "A Nuisance in Kiribati", ticket.Subject)
+
+	found, err = s.Search(ctx, "subject", "Nuisance Missing")
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(found))
+
+	// while a term with spaces is still matched whole.
+	found, err = s.Search(ctx, "created_at", ticket.CreatedAt.String())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []*models.Ticket{ticket}, found)
+}
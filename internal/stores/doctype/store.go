@@ -0,0 +1,50 @@
+// Package doctype defines the store of the documents of one document type,
+// which is implemented for any document type by the hash and inverted packages.
+package doctype
+
+import (
+	"context"
+	"errors"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+)
+
+var ErrInvalidField = errors.New("invalid field")
+
+// CheckInterval is how many documents a search visits between checks that its context is not done.
+const CheckInterval = 256
+
+// Store searches documents of type T, whose _id has type ID.
+// It is not safe for concurrent use, so a store shared by goroutines must be locked by its owner.
+type Store[ID comparable, T models.Model] interface {
+	ListFields() []string
+
+	// Search returns the documents whose field matches query.
+	// It returns the error of ctx if ctx is done before the search is.
+	Search(ctx context.Context, field, query string) ([]T, error)
+
+	// TermCounts returns the number of docs with each term of the field, as it is tokenised to be searched,
+	// so each term is a query that matches the documents counted.
+	// It returns the error of ctx if ctx is done before the terms are counted.
+	TermCounts(ctx context.Context, field string, docs []T) (map[string]int, error)
+
+	// Upsert adds doc, replacing the document with the same _id.
+	// The store keeps doc, so to change a document later, upsert a changed copy of it.
+	Upsert(doc T)
+
+	// Delete removes the document with the _id, and returns it if there was one.
+	Delete(id ID) (T, bool)
+
+	// ParseID parses an _id from a query.
+	ParseID(query string) (ID, error)
+}
+
+// Builder builds a Store from documents added as they are read, so they need not all be held before they are indexed.
+// The computed fields of the documents are set after they have all been read, so they are indexed by Build.
+type Builder[ID comparable, T models.Model] interface {
+	// Add adds docs, whose computed fields are not set yet. The builder keeps the documents but not the slice.
+	Add(docs []T)
+
+	// Build returns the store of the documents added, once their computed fields are set.
+	Build() Store[ID, T]
+}
@@ -0,0 +1,103 @@
+package stores
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidFacet = errors.New("invalid facet")
+
+// FacetKind is how a facet aggregates the values of a field over the documents that match a search.
+type FacetKind string
+
+const (
+	// Terms counts the documents with each term of the field, the most common first.
+	Terms FacetKind = "terms"
+	// DateHistogram counts the documents in each day, month or year of a timestamp field, in order.
+	DateHistogram FacetKind = "histogram"
+	// Stats summarises the numeric values of the field.
+	Stats FacetKind = "stats"
+)
+
+// Interval is the width of the buckets of a DateHistogram.
+type Interval string
+
+const (
+	Day   Interval = "day"
+	Month Interval = "month"
+	Year  Interval = "year"
+)
+
+// Facet requests an aggregation of a field, declared, computed or extra, over the documents that match a search.
+type Facet struct {
+	Field string
+	Kind  FacetKind
+	// Interval is the width of the buckets of a DateHistogram, Month if it is empty.
+	Interval Interval
+}
+
+// ParseFacet parses a facet specification, a field optionally followed by a colon and how it is aggregated:
+// terms, stats, or the interval of a date histogram, day, month or year.
+// For example, "priority" counts the terms of priority, "created_at:month" counts the documents created each month,
+// and "user_count:stats" summarises the user_count.
+func ParseFacet(spec string) (Facet, error) {
+	field, kind, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	facet := Facet{Field: strings.TrimSpace(field), Kind: Terms}
+	if facet.Field == "" {
+		return Facet{}, fmt.Errorf("%w: expected a field, got %q", ErrInvalidFacet, spec)
+	}
+	switch kind := FacetKind(strings.ToLower(strings.TrimSpace(kind))); kind {
+	case "", Terms:
+	case Stats:
+		facet.Kind = Stats
+	case DateHistogram:
+		facet.Kind, facet.Interval = DateHistogram, Month
+	case FacetKind(Day), FacetKind(Month), FacetKind(Year):
+		facet.Kind, facet.Interval = DateHistogram, Interval(kind)
+	default:
+		return Facet{}, fmt.Errorf(
+			"%w: expected terms, stats, day, month or year after %s, got %q", ErrInvalidFacet, facet.Field, kind,
+		)
+	}
+	return facet, nil
+}
+
+// String returns the facet in the form ParseFacet parses.
+func (f Facet) String() string {
+	switch f.Kind {
+	case DateHistogram:
+		interval := f.Interval
+		if interval == "" {
+			interval = Month
+		}
+		return f.Field + ":" + string(interval)
+	case Stats:
+		return f.Field + ":" + string(Stats)
+	default:
+		return f.Field
+	}
+}
+
+// Bucket is the number of documents with a term, or in an interval of a date histogram.
+type Bucket struct {
+	// Key is the term, or the date, month or year of the interval, which is a query that matches the documents counted.
+	Key   string
+	Count int
+}
+
+// Summary summarises numeric values.
+type Summary struct {
+	// Count is the number of values, and the others are 0 if there are none.
+	Count               int
+	Min, Max, Sum, Mean float64
+}
+
+// FacetResult is the aggregation of a field requested by a Facet.
+type FacetResult struct {
+	Facet
+	// Buckets are the counts of a Terms facet, the most common first, or of a DateHistogram, in order.
+	Buckets []Bucket
+	// Summary is the summary of a Stats facet.
+	Summary Summary
+}
@@ -0,0 +1,513 @@
+package stores_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/cache"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/satrap-illustrations/zs/internal/stores/multi"
+	"github.com/satrap-illustrations/zs/internal/stores/reload"
+	"gotest.tools/v3/assert"
+)
+
+// rules are the intentional differences between how the stores match a query to the value of a field.
+// The oracle follows the rules of a store to find the documents it should return.
+type rules struct {
+	// words matches a string by each of its words, split on spaces and trimmed of leading and trailing punctuation,
+	// rather than by its whole value. A query that is not a term of the field, but has several words,
+	// matches the documents with all of them. A string of only spaces has no words, so is not matched at all.
+	words bool
+	// parse matches a query of a number or UUID field by its parsed value, so "07" matches 7 and an upper case UUID
+	// matches, rather than by the value formatted the canonical way.
+	parse bool
+	// zeroUUID matches the zero UUID, which is otherwise left out like a missing value.
+	zeroUUID bool
+	// source is the name of the source of a multi.Store, which an _id can be qualified with, as in "a:101",
+	// to search for the _id after it.
+	source string
+}
+
+var (
+	// hashRules are the rules of the HashStore, which scans the whole value of each document.
+	hashRules = rules{parse: true, zeroUUID: true}
+	// invertedRules are the rules of the InvertedStore, which looks up the words of the query in its index.
+	invertedRules = rules{words: true}
+	// multiRules are the rules of a multi.Store of one InvertedStore, named a.
+	multiRules = rules{words: true, source: "a"}
+)
+
+// terms returns the canonical text of a value, each word of it for a string, as the words rule matches it.
+func terms(value any) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return []string{""}
+		}
+		return words(v)
+	case []string:
+		out := []string{}
+		for _, s := range v {
+			out = append(out, words(s)...)
+		}
+		return out
+	case int:
+		return []string{strconv.Itoa(v)}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case nil:
+		return []string{""}
+	case models.Enum:
+		return []string{v.String()}
+	case models.Timestamp:
+		return v.Terms()
+	case uuid.UUID:
+		if v == (uuid.UUID{}) {
+			return nil
+		}
+		return []string{v.String()}
+	}
+	return nil
+}
+
+// words splits s on spaces and trims each word of punctuation.
+func words(s string) []string {
+	out := []string{}
+	for _, word := range strings.Split(s, " ") {
+		if word != "" {
+			out = append(out, strings.Trim(word, `?!.,;:"'_`))
+		}
+	}
+	return out
+}
+
+// wholeMatches reports whether the whole of value is the query, as the rules without words match it.
+func wholeMatches(value any, query string, r rules) bool {
+	switch v := value.(type) {
+	case string:
+		return v == query
+	case []string:
+		return slices.Contains(v, query)
+	case int:
+		if r.parse {
+			parsed, err := strconv.Atoi(query)
+			return err == nil && parsed == v
+		}
+	case float64:
+		if r.parse {
+			parsed, err := strconv.ParseFloat(query, 64)
+			return err == nil && parsed == v
+		}
+	case uuid.UUID:
+		if v == (uuid.UUID{}) && !r.zeroUUID {
+			return false
+		}
+		if r.parse {
+			parsed, err := uuid.Parse(query)
+			return err == nil && parsed == v
+		}
+	}
+	return slices.Contains(terms(value), query)
+}
+
+// valuesOf returns the values of a declared or extra field of a document, and whether it has the field.
+func valuesOf(doc models.Model, field string) ([]any, bool) {
+	if value, err := doc.ValueAt(field); err == nil {
+		return []any{value}, true
+	}
+	values, ok := doc.ExtraFields().Flatten()[field]
+	return values, ok
+}
+
+// oracle returns the sorted _ids of the documents of the type of zero that a store with the rules finds
+// by a query of the field, by checking each document, and whether the search is an error.
+// Both stores parse a query of _id, so it is matched by the rules of the HashStore.
+func oracle(zero models.Model, docs []models.Model, field, query string, r rules, parseID func(string) error) ([]string, bool) {
+	if field == "_id" {
+		if name, id, qualified := strings.Cut(query, ":"); qualified && name == r.source {
+			query = id
+		}
+		if parseID(query) != nil {
+			return nil, true
+		}
+		r = hashRules
+	}
+
+	_, err := zero.ValueAt(field)
+	valid := err == nil
+	exact := false
+	for _, doc := range docs {
+		values, ok := valuesOf(doc, field)
+		valid = valid || ok
+		for _, value := range values {
+			exact = exact || slices.Contains(terms(value), query)
+		}
+	}
+	if !valid {
+		return nil, true
+	}
+	queryWords := words(query)
+
+	out := []string{}
+	for _, doc := range docs {
+		values, _ := valuesOf(doc, field)
+		docTerms := []string{}
+		matched := false
+		for _, value := range values {
+			if !r.words {
+				matched = matched || wholeMatches(value, query, r)
+				continue
+			}
+			docTerms = append(docTerms, terms(value)...)
+		}
+		if r.words {
+			if exact {
+				matched = slices.Contains(docTerms, query)
+			} else if len(queryWords) > 1 {
+				matched = true
+				for _, word := range queryWords {
+					matched = matched && slices.Contains(docTerms, word)
+				}
+			}
+		}
+		if matched {
+			out = append(out, doc.StringID())
+		}
+	}
+	slices.Sort(out)
+	return out, false
+}
+
+var (
+	fuzzWords = []string{
+		"Ohio", "ohio", "Ohio.", `"Ohio"`, "New", "York", "New York", "Virgin Islands", "a_b", "...",
+		"7", "07", "1.5", "true", "2016", "2016-05", "-10:00", "",
+	}
+	fuzzTimestamps = []string{"", "2016-05-21T11:10:28 -10:00", "2016-05-21T01:00:00 -10:00", "2017-01-02T03:04:05 +01:00"}
+	fuzzUUIDs      = []uuid.UUID{
+		{},
+		uuid.MustParse("9270ed79-35eb-4a38-a46f-35725197ea8d"),
+		uuid.MustParse("7cd6b8d4-2999-4ff2-8cfd-44d05b449226"),
+	}
+	fuzzFloats = []float64{0, 1, 1.5, 7}
+)
+
+// fuzzDocs generates random documents of a few words, so that queries of the same words often match them.
+type fuzzDocs struct {
+	r *rand.Rand
+}
+
+func (g fuzzDocs) word() string {
+	return fuzzWords[g.r.Intn(len(fuzzWords))]
+}
+
+// text joins up to three words, sometimes with two spaces or a leading space.
+func (g fuzzDocs) text() string {
+	parts := make([]string, g.r.Intn(4))
+	for i := range parts {
+		parts[i] = g.word()
+	}
+	sep := " "
+	if g.r.Intn(8) == 0 {
+		sep = "  "
+	}
+	text := strings.Join(parts, sep)
+	if g.r.Intn(8) == 0 {
+		text = " " + text
+	}
+	return text
+}
+
+func (g fuzzDocs) texts() []string {
+	out := make([]string, g.r.Intn(3))
+	for i := range out {
+		out[i] = g.text()
+	}
+	return out
+}
+
+func (g fuzzDocs) uuid() string {
+	return fuzzUUIDs[g.r.Intn(len(fuzzUUIDs))].String()
+}
+
+// common adds the fields of both document types, and some extra fields, to doc.
+func (g fuzzDocs) common(doc map[string]any) map[string]any {
+	doc["external_id"] = g.uuid()
+	if ts := fuzzTimestamps[g.r.Intn(len(fuzzTimestamps))]; ts != "" {
+		doc["created_at"] = ts
+	}
+	doc["tags"] = g.texts()
+	if g.r.Intn(2) == 0 {
+		doc["region"] = g.text()
+	}
+	if g.r.Intn(2) == 0 {
+		doc["score"] = fuzzFloats[g.r.Intn(len(fuzzFloats))]
+	}
+	if g.r.Intn(2) == 0 {
+		doc["nested"] = map[string]any{"flag": g.r.Intn(2) == 0}
+	}
+	if g.r.Intn(4) == 0 {
+		doc["note"] = nil
+	}
+	if g.r.Intn(2) == 0 {
+		doc["list"] = []any{g.text(), fuzzFloats[g.r.Intn(len(fuzzFloats))]}
+	}
+	return doc
+}
+
+func (g fuzzDocs) organizations() []map[string]any {
+	out := make([]map[string]any, g.r.Intn(8))
+	for i := range out {
+		out[i] = g.common(map[string]any{
+			"_id":            101 + i,
+			"name":           g.text(),
+			"domain_names":   g.texts(),
+			"details":        g.text(),
+			"shared_tickets": g.r.Intn(2) == 0,
+		})
+	}
+	return out
+}
+
+func (g fuzzDocs) tickets() []map[string]any {
+	out := make([]map[string]any, g.r.Intn(8))
+	for i := range out {
+		ticket := g.common(map[string]any{
+			"_id":           uuid.NewSHA1(uuid.Nil, []byte{byte(i)}).String(),
+			"subject":       g.text(),
+			"submitter_id":  g.r.Intn(3),
+			"has_incidents": g.r.Intn(2) == 0,
+		})
+		for field, values := range map[string][]string{
+			"type":     models.TicketType("").Values(),
+			"priority": models.TicketPriority("").Values(),
+			"status":   models.TicketStatus("").Values(),
+			"via":      models.TicketVia("").Values(),
+		} {
+			if i := g.r.Intn(len(values) + 1); i < len(values) {
+				ticket[field] = values[i]
+			}
+		}
+		if ts := fuzzTimestamps[g.r.Intn(len(fuzzTimestamps))]; ts != "" {
+			ticket["due_at"] = ts
+		}
+		out[i] = ticket
+	}
+	return out
+}
+
+// fuzzFields are the fields searched, for each document type. They leave out the computed fields,
+// which the oracle does not compute.
+var fuzzFields = map[string][]string{
+	"Organizations": {
+		"_id", "external_id", "name", "domain_names", "created_at", "details", "shared_tickets", "tags",
+		"region", "score", "nested.flag", "note", "list", "missing",
+	},
+	"Tickets": {
+		"_id", "external_id", "created_at", "type", "subject", "priority", "status", "submitter_id", "tags",
+		"has_incidents", "due_at", "via", "region", "score", "nested.flag", "note", "list", "missing",
+	},
+}
+
+// queries returns the queries to pick from for the documents: each word and pair of words,
+// and the text of each value in their canonical and other forms.
+func queries(docs map[string][]models.Model) []string {
+	out := slices.Clone(fuzzWords)
+	for _, a := range fuzzWords {
+		for _, b := range fuzzWords[:4] {
+			out = append(out, a+" "+b)
+		}
+	}
+	for _, ts := range fuzzTimestamps {
+		out = append(out, models.MustParseTimestamp(ts).Terms()...)
+	}
+	for _, id := range fuzzUUIDs {
+		out = append(out, id.String(), strings.ToUpper(id.String()))
+	}
+	for _, docType := range []string{"Organizations", "Tickets"} {
+		for _, doc := range docs[docType] {
+			out = append(out, doc.StringID(), "0"+doc.StringID(), strings.ToUpper(doc.StringID()), "a:"+doc.StringID())
+		}
+	}
+	return append(out, "1.50", "1e0", "+7", "false", " Ohio", "Ohio  York")
+}
+
+// writeFuzzData writes the documents to a data directory, and returns them as they are read back.
+func writeFuzzData(t *testing.T, g fuzzDocs) (string, map[string][]models.Model) {
+	t.Helper()
+
+	dir := t.TempDir()
+	docs := map[string][]models.Model{}
+	for _, file := range []struct {
+		name, docType string
+		docs          []map[string]any
+		read          func([]byte) ([]models.Model, error)
+	}{
+		{name: "organizations.json", docType: "Organizations", docs: g.organizations(), read: readModels[models.Organization]},
+		{name: "tickets.json", docType: "Tickets", docs: g.tickets(), read: readModels[models.Ticket]},
+		{name: "users.json", docType: "Users", docs: []map[string]any{}},
+	} {
+		data, err := json.Marshal(file.docs)
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, file.name), data, 0o600))
+		if file.read != nil {
+			docs[file.docType], err = file.read(data)
+			assert.NilError(t, err)
+		}
+	}
+	return dir, docs
+}
+
+func readModels[T any, PT interface {
+	*T
+	models.Model
+}](data []byte) ([]models.Model, error) {
+	var docs []PT
+	if err := json.Unmarshal(data, &docs); err != nil {
+		return nil, err
+	}
+	out := make([]models.Model, 0, len(docs))
+	for _, doc := range docs {
+		out = append(out, doc)
+	}
+	return out, nil
+}
+
+// FuzzStores searches random documents with every store, and checks each finds the documents the oracle finds
+// with its rules. The seed picks the documents, and field and pick the field and a query from the words in them,
+// unless the fuzzer gives a query itself.
+func FuzzStores(f *testing.F) {
+	for seed := int64(0); seed < 24; seed++ {
+		f.Add(seed, uint8(seed), uint16(seed*31), "")
+	}
+	f.Add(int64(1), uint8(2), uint16(0), "Ohio")
+	f.Add(int64(2), uint8(4), uint16(0), "New York")
+	f.Add(int64(3), uint8(1), uint16(0), "9270ED79-35EB-4A38-A46F-35725197EA8D")
+	f.Add(int64(4), uint8(0), uint16(0), "0101")
+	f.Add(int64(5), uint8(0), uint16(0), "a:0101")
+
+	types := map[string]struct {
+		zero    models.Model
+		parseID func(string) error
+	}{
+		"Organizations": {zero: &models.Organization{}, parseID: func(s string) error { _, err := strconv.Atoi(s); return err }},
+		"Tickets":       {zero: &models.Ticket{}, parseID: func(s string) error { _, err := uuid.Parse(s); return err }},
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, field uint8, pick uint16, query string) {
+		dir, docs := writeFuzzData(t, fuzzDocs{r: rand.New(rand.NewSource(seed))})
+
+		//nolint:staticcheck
+		hashStore, err := implementations.NewHashStore(dir)
+		assert.NilError(t, err)
+		invStore, err := implementations.NewInvertedStore(dir)
+		assert.NilError(t, err)
+		// the stores that wrap another search it with their own paging, merging and caching.
+		multiStore, err := multi.New(multi.Source{Name: "a", Store: invStore})
+		assert.NilError(t, err)
+		reloadStore, err := reload.Watch(dir, invStore, func() (stores.Store, error) { return implementations.NewInvertedStore(dir) })
+		assert.NilError(t, err)
+		t.Cleanup(func() { _ = reloadStore.Close() })
+
+		if query == "" {
+			candidates := queries(docs)
+			query = candidates[int(pick)%len(candidates)]
+		}
+		limit := 1 + int(pick)%4
+		for _, docType := range []string{"Organizations", "Tickets"} {
+			fields := fuzzFields[docType]
+			field := fields[int(field)%len(fields)]
+
+			for _, ts := range []struct {
+				name  string
+				store stores.Store
+				rules rules
+			}{
+				{name: "HashStore", store: hashStore, rules: hashRules},
+				{name: "InvertedStore", store: invStore, rules: invertedRules},
+				{name: "cache.Store", store: cache.New(invStore), rules: invertedRules},
+				{name: "multi.Store", store: multiStore, rules: multiRules},
+				{name: "reload.Store", store: reloadStore, rules: invertedRules},
+			} {
+				expected, expectErr := oracle(types[docType].zero, docs[docType], field, query, ts.rules, types[docType].parseID)
+				for _, search := range []struct {
+					name string
+					ids  func() ([]string, error)
+				}{
+					{name: "search", ids: func() ([]string, error) {
+						hits, err := ts.store.Search(docType, field, query)
+						return idsOf(hits, ts.rules.source), err
+					}},
+					{name: fmt.Sprintf("pages of %d", limit), ids: func() ([]string, error) {
+						return pagedIDs(ts.store, docType, field, query, limit, ts.rules.source)
+					}},
+				} {
+					found, err := search.ids()
+					if expectErr {
+						if err == nil {
+							t.Errorf("%s: %s of %s %s %q found %d documents, expected an error",
+								ts.name, search.name, docType, field, query, len(found))
+						}
+						continue
+					}
+					assert.NilError(t, err, "%s: %s of %s %s %q", ts.name, search.name, docType, field, query)
+
+					slices.Sort(found)
+					if !slices.Equal(expected, found) {
+						t.Errorf("%s: %s of %s %s %q found %q, expected %q",
+							ts.name, search.name, docType, field, query, found, expected)
+					}
+				}
+			}
+		}
+	})
+}
+
+// idsOf returns the _id of the documents that match, without the name of the source they are tagged with.
+// A document tagged with another source than source is returned as its _id qualified with the name of its source,
+// so it differs from what is expected.
+func idsOf(hits []stores.Hit, source string) []string {
+	ids := []string{}
+	for _, doc := range stores.Documents(hits) {
+		if name := models.SourceOf(doc); name != source {
+			ids = append(ids, name+":"+doc.StringID())
+			continue
+		}
+		ids = append(ids, doc.StringID())
+	}
+	return ids
+}
+
+// pagedIDs returns the _id of the documents that match, like idsOf, reading them a page of limit documents at a time.
+func pagedIDs(store stores.Store, docType, field, query string, limit int, source string) ([]string, error) {
+	ids := []string{}
+	request := stores.PageRequest{Limit: limit}
+	for {
+		page, err := store.SearchPage(context.Background(), docType, field, query, request)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, idsOf(page.Hits, source)...)
+		if page.NextCursor == "" {
+			return ids, nil
+		}
+		// a page that repeats documents, or a next page after an empty one, would never end
+		if len(page.Hits) == 0 || len(ids) > page.Total {
+			return nil, fmt.Errorf("%d documents read of %d, with a next page", len(ids), page.Total)
+		}
+		request.Cursor = page.NextCursor
+	}
+}
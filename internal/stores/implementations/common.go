@@ -1,19 +1,458 @@
 package implementations
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+)
+
+var (
+	ErrInvalidDocType = errors.New("invalid document type")
+	ErrNotAnArray     = errors.New("expected an array of documents")
+	// ErrAmbiguousDataFile is returned when a data directory has the documents of a type in more than one file.
+	ErrAmbiguousDataFile = errors.New("more than one data file for the same documents")
+	// ErrUnsupportedDataFile is returned when a data file is in a format its documents cannot be read from.
+	ErrUnsupportedDataFile = errors.New("unsupported data file")
 )
 
-var ErrInvalidDocType = errors.New("invalid document type")
+// Option configures how a store loads its data.
+type Option func(*options)
+
+type options struct {
+	strict bool
+	warn   func(error)
+	now    func() time.Time
+	// location is where the dates of timestamps are searched, nil for the offset they were written with.
+	location *time.Location
+	// progress is called as each data file is read.
+	progress func(Progress)
+}
+
+// WithStrictValidation makes loading fail if a document has an invalid value in an enumerated field.
+func WithStrictValidation() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithWarnings calls warn for each invalid value found while loading, unless validation is strict.
+func WithWarnings(warn func(error)) Option {
+	return func(o *options) {
+		o.warn = warn
+	}
+}
+
+// WithNow sets the time that time dependent computed fields, such as whether a ticket is overdue, are computed at.
+// The default is the time the data is loaded.
+func WithNow(now time.Time) Option {
+	return func(o *options) {
+		o.now = func() time.Time { return now }
+	}
+}
+
+// WithLocation puts the timestamps of the documents in loc, the timezone they are shown in,
+// so they are searched, faceted and sorted by the dates they are shown with. See models.SetLocation.
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{warn: func(error) {}, now: time.Now, progress: func(Progress) {}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// report returns the invalid values found while loading joined into one error in strict mode,
+// and warns about each of them otherwise.
+func (o options) report(invalid []error) error {
+	if o.strict {
+		return errors.Join(invalid...)
+	}
+	for _, err := range invalid {
+		o.warn(err)
+	}
+	return nil
+}
+
+// Progress is how much of a data file has been loaded.
+type Progress struct {
+	// File is the name of the file in the data directory.
+	File string
+	// Documents is the number of documents read from the file so far.
+	Documents int
+	// Read is the number of bytes of the file read so far, out of Size.
+	Read, Size int64
+	// Done is whether the whole file has been read.
+	Done bool
+}
+
+// WithProgress calls progress as each data file is read. The calls for different files are not concurrent,
+// but may be interleaved, as the files are read in parallel.
+func WithProgress(progress func(Progress)) Option {
+	return func(o *options) {
+		o.progress = progress
+	}
+}
+
+// data is the documents read from a data directory, by document type.
+type data map[string][]models.Model
+
+// dataFiles are the files in a data directory, in the order they are read.
+// Groups, comments and audits are not in every export, so their files are optional.
+// Each may instead be a JSON lines file with the same base name, see findDataFile,
+// and organizations and users, which are kept in spreadsheets, a CSV file.
+var dataFiles = []struct {
+	name     string
+	docType  string
+	optional bool
+	csv      bool
+}{
+	{name: "organizations.json", docType: "Organizations", csv: true},
+	{name: "tickets.json", docType: "Tickets"},
+	{name: "users.json", docType: "Users", csv: true},
+	{name: "groups.json", docType: "Groups", optional: true},
+	{name: "comments.json", docType: "Comments", optional: true},
+	{name: "audits.json", docType: "Audits", optional: true},
+}
+
+// loadBatch is the number of documents decoded before they are added to a store, so they can be tokenised in parallel.
+const loadBatch = 1024
+
+// loadStores reads the data files in path in parallel, adding each document to the builder of its type as it is read,
+// then sets the computed fields of the documents and builds the stores.
+func loadStores(path string, o options, builders map[string]typeBuilder) (map[string]typeStore, error) {
+	// the warnings of each file are kept until all the files are read, so they are reported in order.
+	errs := make([]error, len(dataFiles))
+	invalid := make([][]error, len(dataFiles))
+	read := make([][]models.Model, len(dataFiles))
+	var mu sync.Mutex
+	progress := func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		o.progress(p)
+	}
+
+	// the name of the file read for each, which may be a JSON lines file.
+	names := make([]string, len(dataFiles))
+
+	var wg sync.WaitGroup
+	for i, file := range dataFiles {
+		wg.Add(1)
+		go func(i int, name, docType string, csv bool) {
+			defer wg.Done()
+			name, stream, err := findDataFile(path, name, csv)
+			names[i] = name
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = stream(filepath.Join(path, name), prototypes[docType].Clone, func(batch []models.Model) error {
+				for _, doc := range batch {
+					models.SetLocation(doc, o.location)
+					invalid[i] = append(invalid[i], models.Validate(doc)...)
+				}
+				read[i] = append(read[i], batch...)
+				return builders[docType].Add(batch)
+			}, func(p Progress) {
+				p.File = name
+				progress(p)
+			})
+		}(i, file.name, file.docType, file.csv)
+	}
+	wg.Wait()
+
+	for i, file := range dataFiles {
+		if file.optional && errors.Is(errs[i], fs.ErrNotExist) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", names[i], errs[i])
+		}
+		if err := o.report(invalid[i]); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", names[i], err)
+		}
+	}
+
+	d := data{}
+	for i, file := range dataFiles {
+		d[file.docType] = read[i]
+	}
+
+	if err := computeFields(d, o); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]typeStore, len(builders))
+	for docType, builder := range builders {
+		out[docType] = builder.Build()
+	}
+	return out, nil
+}
+
+// prototypes maps each document type to a Model of that type.
+var prototypes = map[string]models.Model{
+	"Organizations": &models.Organization{},
+	"Tickets":       &models.Ticket{},
+	"Users":         &models.User{},
+	"Groups":        &models.Group{},
+	"Comments":      &models.Comment{},
+	"Audits":        &models.Audit{},
+}
+
+// listValues returns the valid values of an enumerated field, or nil if the field is not enumerated.
+func listValues(documentType, field string) []string {
+	prototype, exists := prototypes[documentType]
+	if !exists {
+		return nil
+	}
+	values, _ := models.EnumValues(prototype, field)
+	return values
+}
+
+// streamFunc reads the documents in the file at path, creating them with newDoc and passing them to add in batches of up to
+// loadBatch. progress is called after each batch.
+type streamFunc func(path string, newDoc func() models.Model, add func(batch []models.Model) error, progress func(Progress)) error
+
+// dataFormats are the formats a data file may be in, by extension.
+var dataFormats = []struct {
+	ext    string
+	stream streamFunc
+}{
+	{ext: ".json", stream: streamJSONFile},
+	{ext: ".ndjson", stream: streamJSONLinesFile},
+	{ext: ".jsonl", stream: streamJSONLinesFile},
+	{ext: ".csv", stream: streamCSVFile},
+}
+
+// findDataFile returns the file in dir with the documents of the data file name, and how to stream it.
+// That is name, a JSON array, unless there is a file with the same base name in another of dataFormats,
+// e.g. tickets.ndjson or users.csv for tickets.json or users.json, or either gzipped with the extension .gz,
+// e.g. tickets.json.gz. A CSV file is only read if csv is set, and is ErrUnsupportedDataFile otherwise.
+// It returns ErrAmbiguousDataFile if there is more than one, and name if there is none,
+// so reading it fails as the file does not exist.
+func findDataFile(dir, name string, csv bool) (string, streamFunc, error) {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	found := []string{}
+	stream := streamFunc(streamJSONFile)
+	for _, format := range dataFormats {
+		for _, candidate := range []string{base + format.ext, base + format.ext + ".gz"} {
+			if _, err := os.Stat(filepath.Join(dir, candidate)); err == nil {
+				if format.ext == ".csv" && !csv {
+					return candidate, nil, fmt.Errorf("%w: only users and organizations can be read from CSV files", ErrUnsupportedDataFile)
+				}
+				found = append(found, candidate)
+				stream = format.stream
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return name, streamJSONFile, nil
+	case 1:
+		return found[0], stream, nil
+	default:
+		return name, nil, fmt.Errorf("%w: %s", ErrAmbiguousDataFile, strings.Join(found, ", "))
+	}
+}
+
+// batches collects the documents split out of a file, and unmarshals and adds them in batches of loadBatch.
+type batches struct {
+	newDoc func() models.Model
+	add    func(batch []models.Model) error
+	raw    []json.RawMessage
+	// unit is what where counts, documents or lines, and where is the position of each of raw in the file, for errors.
+	unit  string
+	where []int
+	// count is the number of documents added.
+	count int
+}
+
+func newBatches(unit string, newDoc func() models.Model, add func(batch []models.Model) error) *batches {
+	return &batches{
+		newDoc: newDoc,
+		add:    add,
+		raw:    make([]json.RawMessage, 0, loadBatch),
+		unit:   unit,
+		where:  make([]int, 0, loadBatch),
+	}
+}
+
+// push adds the document doc, found at where, and reports whether the batch is full.
+func (b *batches) push(doc json.RawMessage, where int) bool {
+	b.raw = append(b.raw, doc)
+	b.where = append(b.where, where)
+	return len(b.raw) == loadBatch
+}
+
+// flush unmarshals the documents of the batch in parallel and adds them.
+func (b *batches) flush() error {
+	batch, err := unmarshalAll(b.raw, b.newDoc)
+	if err != nil {
+		return fmt.Errorf("%s %d: %w", b.unit, b.where[len(batch)], err)
+	}
+	if err := b.add(batch); err != nil {
+		return err
+	}
+	b.count += len(batch)
+	b.raw, b.where = b.raw[:0], b.where[:0]
+	return nil
+}
+
+// streamJSONFile decodes the array of documents in the file at path one at a time, so the file is never held in memory whole.
+// This, like the other streamFuncs, decompresses a gzipped file, see openDataFile.
+func streamJSONFile(
+	path string,
+	newDoc func() models.Model,
+	add func(batch []models.Model) error,
+	progress func(Progress),
+) error {
+	f, err := openDataFile(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
 
-func readJSONFile(path string, v any) error {
-	f, err := os.Open(path)
+	dec := json.NewDecoder(f)
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		// null, like an empty array
+		progress(Progress{Read: f.read(dec.InputOffset()), Size: f.size, Done: true})
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("%w, found %v", ErrNotAnArray, token)
+	}
+
+	// the documents of a batch are split out of the file one at a time, then unmarshalled in parallel.
+	b := newBatches("document", newDoc, add)
+	flush := func() error {
+		if err := b.flush(); err != nil {
+			return err
+		}
+		progress(Progress{Documents: b.count, Read: f.read(dec.InputOffset()), Size: f.size})
+		return nil
+	}
+	for dec.More() {
+		where := b.count + len(b.raw)
+		var doc json.RawMessage
+		if err := dec.Decode(&doc); err != nil {
+			return fmt.Errorf("document %d: %w", where, err)
+		}
+		if b.push(doc, where) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(b.raw) > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	progress(Progress{Documents: b.count, Read: f.read(dec.InputOffset()), Size: f.size, Done: true})
+	return nil
+}
+
+// streamJSONLinesFile reads the file at path a line at a time, each a JSON document, skipping blank lines.
+// Its errors give the number of the line, counted from 1.
+func streamJSONLinesFile(
+	path string,
+	newDoc func() models.Model,
+	add func(batch []models.Model) error,
+	progress func(Progress),
+) error {
+	f, err := openDataFile(path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	return json.NewDecoder(f).Decode(v)
+	r := bufio.NewReaderSize(f, 1<<16)
+	b := newBatches("line", newDoc, add)
+	var read int64
+	flush := func() error {
+		if err := b.flush(); err != nil {
+			return err
+		}
+		progress(Progress{Documents: b.count, Read: f.read(read), Size: f.size})
+		return nil
+	}
+	for line := 1; ; line++ {
+		// the line is a new slice, so it is kept in the batch without copying it.
+		data, err := r.ReadBytes('\n')
+		read += int64(len(data))
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if doc := bytes.TrimSpace(data); len(doc) > 0 {
+			if b.push(doc, line) {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	if len(b.raw) > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	progress(Progress{Documents: b.count, Read: f.read(read), Size: f.size, Done: true})
+	return nil
+}
+
+// unmarshalAll unmarshals each of raw into a document created with newDoc, on a goroutine for each CPU.
+// If any fail, it returns the documents before the first that failed, and its error.
+func unmarshalAll(raw []json.RawMessage, newDoc func() models.Model) ([]models.Model, error) {
+	docs := make([]models.Model, len(raw))
+	errs := make([]error, len(raw))
+	workers := max(1, min(runtime.GOMAXPROCS(0), len(raw)))
+	chunk := (len(raw) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(raw); start += chunk {
+		end := min(start+chunk, len(raw))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				docs[i] = newDoc()
+				errs[i] = json.Unmarshal(raw[i], docs[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return docs[:i], err
+		}
+	}
+	return docs, nil
 }
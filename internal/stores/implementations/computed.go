@@ -0,0 +1,65 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/satrap-illustrations/zs/internal/graph"
+	"github.com/satrap-illustrations/zs/internal/models"
+)
+
+// computeFields sets the computed fields of every document in d.
+func computeFields(d data, o options) error {
+	traverser := graph.NewTraverser(newDataSearcher(d), models.Relations)
+	c := models.ComputeContext{
+		Now: o.now(),
+		Related: func(doc models.Model, relation string) ([]models.Model, error) {
+			return traverser.Related(context.Background(), doc, relation)
+		},
+	}
+	for _, docs := range d {
+		for _, doc := range docs {
+			if err := models.Compute(doc, c); err != nil {
+				return fmt.Errorf("failed to compute fields of %s %s: %w", doc.DocumentType(), doc.StringID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// dataSearcher searches the documents read from a data directory before the stores are built.
+// It indexes each field by its whole value the first time it is searched, which is all relations need.
+type dataSearcher struct {
+	docs map[string][]models.Model
+	// index maps a document type and field to the documents with each value of the field.
+	index map[[2]string]map[string][]models.Model
+}
+
+func newDataSearcher(d data) *dataSearcher {
+	s := &dataSearcher{
+		docs:  map[string][]models.Model{},
+		index: map[[2]string]map[string][]models.Model{},
+	}
+	for docType, docs := range d {
+		s.docs[prototypes[docType].DocumentType()] = docs
+	}
+	return s
+}
+
+func (s *dataSearcher) SearchLike(_ context.Context, like models.Model, field, query string) ([]models.Model, error) {
+	key := [2]string{like.DocumentType(), field}
+	byValue, exists := s.index[key]
+	if !exists {
+		byValue = map[string][]models.Model{}
+		for _, doc := range s.docs[like.DocumentType()] {
+			value, err := doc.ValueAt(field)
+			if err != nil {
+				return nil, err
+			}
+			v := fmt.Sprint(value)
+			byValue[v] = append(byValue[v], doc)
+		}
+		s.index[key] = byValue
+	}
+	return byValue[query], nil
+}
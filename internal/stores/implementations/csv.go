@@ -0,0 +1,250 @@
+package implementations
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/satrap-illustrations/zs/internal/models"
+)
+
+// ErrInvalidMapping is returned when the column mapping of a CSV file cannot be used to read it.
+var ErrInvalidMapping = errors.New("invalid column mapping")
+
+// csvMapping is how the columns of a CSV file map to the fields of its documents.
+// It is read from a JSON file next to the CSV file, with the extension .mapping.json instead of .csv,
+// e.g. users.mapping.json for users.csv or users.csv.gz.
+type csvMapping struct {
+	// Columns maps the header of a column to the field it is read into. A column that is not mapped is read
+	// into the field named by its header, and one mapped to "" is left out.
+	// A field that is not declared by the document type is an extra field, nested by its dotted path.
+	Columns map[string]string `json:"columns"`
+	// Delimiter separates the cells of a row, a comma if empty.
+	Delimiter string `json:"delimiter"`
+	// Separator separates the values of a list field, such as tags, in a cell, a comma if empty.
+	Separator string `json:"separator"`
+}
+
+// readCSVMapping reads the mapping of the CSV file at path, or returns the default mapping if there is no mapping file.
+func readCSVMapping(path string) (csvMapping, error) {
+	m := csvMapping{}
+	data, err := os.ReadFile(strings.TrimSuffix(strings.TrimSuffix(path, ".gz"), ".csv") + ".mapping.json")
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return m, err
+	default:
+		if err := json.Unmarshal(data, &m); err != nil {
+			return m, fmt.Errorf("%w: %w", ErrInvalidMapping, err)
+		}
+	}
+	if m.Delimiter == "" {
+		m.Delimiter = ","
+	}
+	if m.Separator == "" {
+		m.Separator = ","
+	}
+	if utf8.RuneCountInString(m.Delimiter) != 1 {
+		return m, fmt.Errorf("%w: the delimiter must be one character, found %q", ErrInvalidMapping, m.Delimiter)
+	}
+	return m, nil
+}
+
+// csvColumn is a column of a CSV file, and how to convert its cells to the JSON value of its field.
+type csvColumn struct {
+	header  string
+	field   string
+	convert func(cell string) (any, error)
+}
+
+// columns returns the columns of a CSV file with the header, reading documents like prototype.
+// The columns that are left out are nil.
+func (m csvMapping) columns(header []string, prototype models.Model) ([]*csvColumn, error) {
+	out := make([]*csvColumn, len(header))
+	mapped := map[string]string{}
+	for i, name := range header {
+		field, exists := m.Columns[name]
+		if !exists {
+			field = name
+		}
+		if field == "" {
+			continue
+		}
+		if previous, exists := mapped[field]; exists {
+			return nil, fmt.Errorf("%w: columns %q and %q are both mapped to %s", ErrInvalidMapping, previous, name, field)
+		}
+		mapped[field] = name
+		if models.IsComputed(prototype, field) {
+			return nil, fmt.Errorf("%w: column %q is mapped to the computed field %s", ErrInvalidMapping, name, field)
+		}
+
+		convert := func(cell string) (any, error) { return cell, nil }
+		if zero, err := prototype.ValueAt(field); err == nil {
+			if convert, err = m.converter(zero); err != nil {
+				return nil, fmt.Errorf("%w: column %q: %w", ErrInvalidMapping, name, err)
+			}
+		}
+		out[i] = &csvColumn{header: name, field: field, convert: convert}
+	}
+	// a field inside another would overwrite it, or be overwritten by it, when the document is built
+	for _, column := range out {
+		if column == nil {
+			continue
+		}
+		names := strings.Split(column.field, ".")
+		for i := 1; i < len(names); i++ {
+			parent := strings.Join(names[:i], ".")
+			if other, exists := mapped[parent]; exists {
+				return nil, fmt.Errorf("%w: column %q is mapped to %s, inside the field %s of column %q",
+					ErrInvalidMapping, column.header, column.field, parent, other)
+			}
+		}
+	}
+	return out, nil
+}
+
+// converter returns how to convert a cell to the JSON value of a field whose zero value is zero.
+// An empty cell is nil, which leaves the field out, except for a list, which is empty.
+func (m csvMapping) converter(zero any) (func(string) (any, error), error) {
+	nilIfEmpty := func(convert func(string) (any, error)) func(string) (any, error) {
+		return func(cell string) (any, error) {
+			if cell = strings.TrimSpace(cell); cell == "" {
+				return nil, nil
+			}
+			return convert(cell)
+		}
+	}
+
+	switch zero.(type) {
+	case string, models.Enum, models.Timestamp:
+		// enums and timestamps are validated as the document is unmarshalled
+		return func(cell string) (any, error) { return cell, nil }, nil
+	case []string:
+		return func(cell string) (any, error) {
+			out := []string{}
+			for _, value := range strings.Split(cell, m.Separator) {
+				if value = strings.TrimSpace(value); value != "" {
+					out = append(out, value)
+				}
+			}
+			return out, nil
+		}, nil
+	case int:
+		return nilIfEmpty(func(cell string) (any, error) { return strconv.Atoi(cell) }), nil
+	case bool:
+		return nilIfEmpty(func(cell string) (any, error) { return strconv.ParseBool(cell) }), nil
+	case uuid.UUID:
+		return nilIfEmpty(func(cell string) (any, error) { return uuid.Parse(cell) }), nil
+	default:
+		return nil, fmt.Errorf("%w: %T cannot be read from a cell", models.ErrFieldType, zero)
+	}
+}
+
+// setPath sets the value at the dotted path in doc, creating the objects it is nested in.
+func setPath(doc map[string]any, path string, value any) {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		child, ok := doc[name].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			doc[name] = child
+		}
+		doc = child
+	}
+	doc[names[len(names)-1]] = value
+}
+
+// streamCSVFile reads the file at path as CSV with a header row, converting each row to a document with readCSVMapping.
+// Its errors give the number of the line, counted from 1.
+func streamCSVFile(
+	path string,
+	newDoc func() models.Model,
+	add func(batch []models.Model) error,
+	progress func(Progress),
+) error {
+	mapping, err := readCSVMapping(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := openDataFile(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		progress(Progress{Read: f.read(r.InputOffset()), Size: f.size, Done: true})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// spreadsheets often start the file with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	columns, err := mapping.columns(header, newDoc())
+	if err != nil {
+		return err
+	}
+
+	b := newBatches("line", newDoc, add)
+	flush := func() error {
+		if err := b.flush(); err != nil {
+			return err
+		}
+		progress(Progress{Documents: b.count, Read: f.read(r.InputOffset()), Size: f.size})
+		return nil
+	}
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := r.FieldPos(0)
+
+		doc := map[string]any{}
+		for i, cell := range row {
+			column := columns[i]
+			if column == nil {
+				continue
+			}
+			value, err := column.convert(cell)
+			if err != nil {
+				return fmt.Errorf("line %d: column %q: %w", line, column.header, err)
+			}
+			if value != nil {
+				setPath(doc, column.field, value)
+			}
+		}
+		raw, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if b.push(raw, line) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(b.raw) > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	progress(Progress{Documents: b.count, Read: f.read(r.InputOffset()), Size: f.size, Done: true})
+	return nil
+}
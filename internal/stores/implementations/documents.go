@@ -0,0 +1,519 @@
+package implementations
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/satrap-illustrations/zs/internal/graph"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype"
+)
+
+// typeStore is a doctype.Store whose results are converted to Models,
+// so the stores of all the document types can be kept together.
+type typeStore interface {
+	ListFields() []string
+	Search(ctx context.Context, field, query string) ([]models.Model, error)
+	TermCounts(ctx context.Context, field string, docs []models.Model) (map[string]int, error)
+	Upsert(doc models.Model) error
+	Delete(id string) (models.Model, error)
+}
+
+type modelStore[ID comparable, T models.Model] struct {
+	doctype.Store[ID, T]
+}
+
+func (s modelStore[ID, T]) Search(ctx context.Context, field, query string) ([]models.Model, error) {
+	found, err := s.Store.Search(ctx, field, query)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.Model, 0, len(found))
+	for _, doc := range found {
+		out = append(out, doc)
+	}
+	return out, nil
+}
+
+func (s modelStore[ID, T]) TermCounts(ctx context.Context, field string, docs []models.Model) (map[string]int, error) {
+	ts := make([]T, 0, len(docs))
+	for _, doc := range docs {
+		t, ok := doc.(T)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDocType, doc.DocumentType())
+		}
+		ts = append(ts, t)
+	}
+	return s.Store.TermCounts(ctx, field, ts)
+}
+
+func (s modelStore[ID, T]) Upsert(doc models.Model) error {
+	t, ok := doc.(T)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidDocType, doc.DocumentType())
+	}
+	s.Store.Upsert(t)
+	return nil
+}
+
+func (s modelStore[ID, T]) Delete(id string) (models.Model, error) {
+	parsed, err := s.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	doc, exists := s.Store.Delete(parsed)
+	if !exists {
+		var zero T
+		return nil, fmt.Errorf("%w: %s %s", stores.ErrDocumentNotFound, zero.DocumentType(), id)
+	}
+	return doc, nil
+}
+
+// typeBuilder is a doctype.Builder of a typeStore, which adds Models, so the builders of all the document types can be kept together.
+type typeBuilder interface {
+	Add(docs []models.Model) error
+	Build() typeStore
+}
+
+type modelBuilder[ID comparable, T models.Model] struct {
+	doctype.Builder[ID, T]
+}
+
+func (b modelBuilder[ID, T]) Add(docs []models.Model) error {
+	ts := make([]T, 0, len(docs))
+	for _, doc := range docs {
+		t, ok := doc.(T)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidDocType, doc.DocumentType())
+		}
+		ts = append(ts, t)
+	}
+	b.Builder.Add(ts)
+	return nil
+}
+
+func (b modelBuilder[ID, T]) Build() typeStore {
+	return modelStore[ID, T]{Store: b.Builder.Build()}
+}
+
+// The _id of each document type, for the doctype stores.
+func organizationID(o *models.Organization) int { return o.ID }
+func ticketID(t *models.Ticket) uuid.UUID       { return t.ID }
+func userID(u *models.User) int                 { return u.ID }
+func groupID(g *models.Group) int               { return g.ID }
+func commentID(c *models.Comment) int           { return c.ID }
+func auditID(a *models.Audit) int               { return a.ID }
+
+var (
+	parseIntID  = strconv.Atoi
+	parseUUIDID = uuid.Parse
+)
+
+// documentStores implements stores.Store over a typeStore for each document type.
+// It is safe for concurrent use: any number of searches can run at once, and changes wait for them.
+type documentStores struct {
+	// mu guards the stores, and the documents in them, which are replaced rather than changed in place.
+	mu     sync.RWMutex
+	stores map[string]typeStore
+	opts   options
+	// version counts the changes to the documents, see stores.Versioned.
+	version atomic.Uint64
+}
+
+// unlocked searches the documentStores without locking them, for the traversals of a caller that holds the lock.
+type unlocked struct {
+	s *documentStores
+}
+
+func (u unlocked) SearchLike(ctx context.Context, like models.Model, field, query string) ([]models.Model, error) {
+	return u.s.searchLike(ctx, like, field, query)
+}
+
+func (s *documentStores) traverser() graph.Traverser {
+	return graph.NewTraverser(unlocked{s: s}, models.Relations)
+}
+
+// memoSearcher remembers the documents found by each search of a graph.Searcher.
+// It is used while the documents related to the hits of one search are found,
+// where the same documents, such as the organization of many tickets, are searched for again and again.
+type memoSearcher struct {
+	searcher graph.Searcher
+	found    map[[3]string][]models.Model
+}
+
+func (m memoSearcher) SearchLike(ctx context.Context, like models.Model, field, query string) ([]models.Model, error) {
+	key := [3]string{like.DocumentType(), field, query}
+	if found, ok := m.found[key]; ok {
+		return found, nil
+	}
+	found, err := m.searcher.SearchLike(ctx, like, field, query)
+	if err != nil {
+		return nil, err
+	}
+	m.found[key] = found
+	return found, nil
+}
+
+// Version returns the number of changes made to the documents. See stores.Versioned.
+func (s *documentStores) Version() uint64 {
+	return s.version.Load()
+}
+
+func (*documentStores) ListDocumentTypes() []string {
+	out := make([]string, 0, len(dataFiles))
+	for _, file := range dataFiles {
+		out = append(out, file.docType)
+	}
+	return out
+}
+
+func (s *documentStores) ListFields() map[string][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string][]string, len(s.stores))
+	for docType, store := range s.stores {
+		out[docType] = store.ListFields()
+	}
+	return out
+}
+
+// ListValues returns the valid values of an enumerated field, or nil if any value is valid.
+func (*documentStores) ListValues(documentType, field string) []string {
+	return listValues(documentType, field)
+}
+
+func (s *documentStores) Search(documentType, field, query string) ([]stores.Hit, error) {
+	return s.SearchContext(context.Background(), documentType, field, query)
+}
+
+// SearchContext searches like Search, and stops with the error of ctx once ctx is done.
+func (s *documentStores) SearchContext(ctx context.Context, documentType, field, query string) ([]stores.Hit, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	store, exists := s.stores[documentType]
+	if !exists {
+		return nil, ErrInvalidDocType
+	}
+	sameTypeModels, err := store.Search(ctx, field, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.hits(ctx, field, sameTypeModels)
+}
+
+// SearchPage returns a page of the documents that match, in the order of the sort and then their _id.
+// See stores.Store.SearchPage.
+func (s *documentStores) SearchPage(
+	ctx context.Context,
+	documentType, field, query string,
+	page stores.PageRequest,
+) (stores.Page, error) {
+	if page.Limit < 0 || page.Offset < 0 {
+		return stores.Page{}, fmt.Errorf("%w: limit %d and offset %d", stores.ErrInvalidPage, page.Limit, page.Offset)
+	}
+	search := pageCursor{DocumentType: documentType, Field: field, Query: query, Sort: page.Sort.String()}
+	var after *pageCursor
+	var afterValues []any
+	if page.Cursor != "" {
+		c, err := decodePageCursor(page.Cursor)
+		if err != nil {
+			return stores.Page{}, err
+		}
+		if c.DocumentType != documentType || c.Field != field || c.Query != query {
+			return stores.Page{}, fmt.Errorf("%w: the cursor is from another search", stores.ErrInvalidPage)
+		}
+		if c.Sort != search.Sort {
+			return stores.Page{}, fmt.Errorf("%w: the cursor is from another sort", stores.ErrInvalidPage)
+		}
+		if afterValues, err = decodeSortValues(documentType, page.Sort, c.Values); err != nil {
+			return stores.Page{}, err
+		}
+		after = &c
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	store, exists := s.stores[documentType]
+	if !exists {
+		return stores.Page{}, ErrInvalidDocType
+	}
+	if err := checkSort(documentType, page.Sort, store.ListFields()); err != nil {
+		return stores.Page{}, err
+	}
+	found, err := store.Search(ctx, field, query)
+	if err != nil {
+		return stores.Page{}, err
+	}
+	facets, err := aggregate(ctx, documentType, store, found, page.Facets)
+	if err != nil {
+		return stores.Page{}, err
+	}
+	matches := sortMatches(found, page.Sort)
+
+	start := min(page.Offset, len(matches))
+	if after != nil {
+		// the documents up to the last one on the previous page are skipped, even if it has since been changed or deleted.
+		start, _ = slices.BinarySearchFunc(matches, after.After, func(m sorted, id string) int {
+			return compareSorted(page.Sort, m.values, m.doc.StringID(), afterValues, id)
+		})
+		if start < len(matches) && matches[start].doc.StringID() == after.After {
+			start++
+		}
+	}
+	end := len(matches)
+	if page.Limit > 0 {
+		end = min(start+page.Limit, len(matches))
+	}
+
+	onPage := make([]models.Model, 0, end-start)
+	for _, m := range matches[start:end] {
+		onPage = append(onPage, m.doc)
+	}
+	hits, err := s.hits(ctx, field, onPage)
+	if err != nil {
+		return stores.Page{}, err
+	}
+	out := stores.Page{Hits: hits, Total: len(matches), Facets: facets}
+	if end < len(matches) && end > start {
+		last := matches[end-1]
+		search.After = last.doc.StringID()
+		if search.Values, err = encodeSortValues(last.values); err != nil {
+			return stores.Page{}, err
+		}
+		out.NextCursor = search.encode()
+	}
+	return out, nil
+}
+
+// hits returns a Hit for each document that matched the field, with the documents related to it by each relation.
+func (s *documentStores) hits(ctx context.Context, field string, matches []models.Model) ([]stores.Hit, error) {
+	searcher := memoSearcher{searcher: unlocked{s: s}, found: map[[3]string][]models.Model{}}
+	traverser := graph.NewTraverser(searcher, models.Relations)
+	out := make([]stores.Hit, 0, len(matches))
+	for _, m := range matches {
+		neighbours, err := traverser.Neighbours(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, stores.NewHit(m, []string{field}, neighbours))
+	}
+	return out, nil
+}
+
+// Related follows the named relations from doc. See graph.Traverser.Related.
+func (s *documentStores) Related(doc models.Model, path ...string) ([]models.Model, error) {
+	return s.RelatedContext(context.Background(), doc, path...)
+}
+
+// RelatedContext follows relations like Related, and stops with the error of ctx once ctx is done.
+func (s *documentStores) RelatedContext(ctx context.Context, doc models.Model, path ...string) ([]models.Model, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.traverser().Related(ctx, doc, path...)
+}
+
+// Walk follows every relation from doc up to depth hops. See graph.Traverser.Walk.
+func (s *documentStores) Walk(doc models.Model, depth int) ([]models.Model, error) {
+	return s.WalkContext(context.Background(), doc, depth)
+}
+
+// WalkContext follows relations like Walk, and stops with the error of ctx once ctx is done.
+func (s *documentStores) WalkContext(ctx context.Context, doc models.Model, depth int) ([]models.Model, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.traverser().Walk(ctx, doc, depth)
+}
+
+// SearchLike searches the store for documents of the same type as like, without related documents.
+func (s *documentStores) SearchLike(ctx context.Context, like models.Model, field, query string) ([]models.Model, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.searchLike(ctx, like, field, query)
+}
+
+func (s *documentStores) searchLike(ctx context.Context, like models.Model, field, query string) ([]models.Model, error) {
+	_, store, err := s.storeOf(like)
+	if err != nil {
+		return nil, err
+	}
+	return store.Search(ctx, field, query)
+}
+
+// Upsert adds a copy of doc with its computed fields to the store of its type, replacing the document with the same _id,
+// and updates the computed fields of the documents related to it before and after.
+// A document wrapped with its relation or source, as Related returns it, is upserted without them.
+// If the copy cannot be stored, the document it replaced is kept.
+func (s *documentStores) Upsert(doc models.Model) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the computed fields of the related documents may be changed even if the upsert fails part way.
+	defer s.version.Add(1)
+
+	doc = models.Unwrap(doc)
+	docType, store, err := s.storeOf(doc)
+	if err != nil {
+		return err
+	}
+	// the timestamps of the copy are moved to the location, so doc keeps the offsets it was written with
+	stored := doc.Clone()
+	models.SetLocation(stored, s.opts.location)
+	if err := s.opts.report(models.Validate(stored)); err != nil {
+		return err
+	}
+
+	traverser := s.traverser()
+	if err := models.Compute(stored, s.computeContext(traverser)); err != nil {
+		return fmt.Errorf("failed to compute fields of %s %s: %w", docType, doc.StringID(), err)
+	}
+	old, err := store.Delete(doc.StringID())
+	if err != nil && !errors.Is(err, stores.ErrDocumentNotFound) {
+		return err
+	}
+	if err := store.Upsert(stored); err != nil {
+		// the document replaced is put back, so a failed upsert loses nothing
+		if old != nil {
+			err = errors.Join(err, store.Upsert(old))
+		}
+		return err
+	}
+	affected := []models.Model{stored}
+	if old != nil {
+		affected = append(affected, old)
+	}
+	neighbours := []models.Model{}
+	for _, d := range affected {
+		found, err := traverser.Neighbours(context.Background(), d)
+		if err != nil {
+			return err
+		}
+		neighbours = append(neighbours, found...)
+	}
+	return s.recompute(neighbours)
+}
+
+// Delete removes the document of the given type with the given _id,
+// and updates the computed fields of the documents that were related to it.
+func (s *documentStores) Delete(documentType, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.version.Add(1)
+
+	store, exists := s.stores[documentType]
+	if !exists {
+		return ErrInvalidDocType
+	}
+	doc, err := store.Delete(id)
+	if err != nil {
+		return err
+	}
+	neighbours, err := s.traverser().Neighbours(context.Background(), doc)
+	if err != nil {
+		return err
+	}
+	return s.recompute(neighbours)
+}
+
+// recompute replaces docs with copies with their computed fields updated.
+// Copies are made because the documents may still be used by the callers of earlier searches.
+func (s *documentStores) recompute(docs []models.Model) error {
+	traverser := s.traverser()
+	done := map[[2]string]bool{}
+	for _, doc := range docs {
+		if related, ok := doc.(*models.RelatedModel); ok {
+			doc = related.Model
+		}
+		key := [2]string{doc.DocumentType(), doc.StringID()}
+		if done[key] {
+			continue
+		}
+		done[key] = true
+
+		_, store, err := s.storeOf(doc)
+		if err != nil {
+			return err
+		}
+		clone := doc.Clone()
+		if err := models.Compute(clone, s.computeContext(traverser)); err != nil {
+			return fmt.Errorf("failed to compute fields of %s %s: %w", doc.DocumentType(), doc.StringID(), err)
+		}
+		if err := store.Upsert(clone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *documentStores) computeContext(traverser graph.Traverser) models.ComputeContext {
+	return models.ComputeContext{
+		Now: s.opts.now(),
+		Related: func(doc models.Model, relation string) ([]models.Model, error) {
+			return traverser.Related(context.Background(), doc, relation)
+		},
+	}
+}
+
+// storeOf returns the document type of doc and its store.
+func (s *documentStores) storeOf(doc models.Model) (string, typeStore, error) {
+	for docType, prototype := range prototypes {
+		if prototype.DocumentType() == doc.DocumentType() {
+			return docType, s.stores[docType], nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: %s", ErrInvalidDocType, doc.DocumentType())
+}
+
+// pageCursor is the position after the last document of a page of a search, which Page.NextCursor encodes.
+type pageCursor struct {
+	DocumentType string `json:"t"`
+	Field        string `json:"f"`
+	Query        string `json:"q"`
+	// Sort is the sort of the search, as stores.Sort.String formats it.
+	Sort string `json:"s,omitempty"`
+	// After is the _id of the last document on the page.
+	After string `json:"a"`
+	// Values are the values of the fields in the sort of the last document on the page.
+	Values []json.RawMessage `json:"v,omitempty"`
+}
+
+func (c pageCursor) encode() string {
+	buf, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func decodePageCursor(s string) (pageCursor, error) {
+	c := pageCursor{}
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(buf, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("%w: malformed cursor: %w", stores.ErrInvalidPage, err)
+	}
+	return c, nil
+}
+
+// compareIDs orders _ids, integers numerically and UUIDs as strings.
+func compareIDs(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return cmp.Compare(x, y)
+	}
+	return cmp.Compare(a, b)
+}
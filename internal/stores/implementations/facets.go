@@ -0,0 +1,151 @@
+package implementations
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+)
+
+// intervalLayouts are the layouts of the terms of a timestamp field that are the keys of each interval of a histogram.
+var intervalLayouts = map[stores.Interval]string{
+	stores.Day:   models.DayLayout,
+	stores.Month: models.MonthLayout,
+	stores.Year:  models.YearLayout,
+}
+
+// aggregate computes the facets over the documents that match, using the store to count terms.
+func aggregate(
+	ctx context.Context,
+	documentType string,
+	store typeStore,
+	matches []models.Model,
+	facets []stores.Facet,
+) ([]stores.FacetResult, error) {
+	fields := store.ListFields()
+	out := make([]stores.FacetResult, 0, len(facets))
+	for _, facet := range facets {
+		if !slices.Contains(fields, facet.Field) {
+			return nil, fmt.Errorf("%w: %s has no field %s", stores.ErrInvalidFacet, documentType, facet.Field)
+		}
+		result := stores.FacetResult{Facet: facet}
+		var err error
+		switch facet.Kind {
+		case stores.Terms:
+			result.Buckets, err = termBuckets(ctx, store, facet.Field, matches)
+		case stores.DateHistogram:
+			result.Buckets, err = histogramBuckets(ctx, documentType, store, facet, matches)
+		case stores.Stats:
+			result.Summary, err = summarise(documentType, facet.Field, matches)
+		default:
+			err = fmt.Errorf("%w: unknown kind %q of %s", stores.ErrInvalidFacet, facet.Kind, facet.Field)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, result)
+	}
+	return out, nil
+}
+
+// termBuckets counts the documents with each term of the field, the most common first, and then in the order of the terms.
+func termBuckets(ctx context.Context, store typeStore, field string, matches []models.Model) ([]stores.Bucket, error) {
+	counts, err := store.TermCounts(ctx, field, matches)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]stores.Bucket, 0, len(counts))
+	for term, count := range counts {
+		out = append(out, stores.Bucket{Key: term, Count: count})
+	}
+	slices.SortFunc(out, func(a, b stores.Bucket) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return out, nil
+}
+
+// histogramBuckets counts the documents in each interval of a timestamp field, in order.
+// A timestamp is indexed by its date, month and year, so the buckets are the counts of the terms in the layout of the interval.
+// The intervals are in the location given by WithLocation, which the timestamps are moved to as they are stored,
+// so they are those that are searched. Without one, they are in the offset each timestamp was written with.
+func histogramBuckets(
+	ctx context.Context,
+	documentType string,
+	store typeStore,
+	facet stores.Facet,
+	matches []models.Model,
+) ([]stores.Bucket, error) {
+	value, err := prototypes[documentType].ValueAt(facet.Field)
+	if _, isTimestamp := value.(models.Timestamp); err != nil || !isTimestamp {
+		return nil, fmt.Errorf("%w: %s %s is not a timestamp", stores.ErrInvalidFacet, documentType, facet.Field)
+	}
+	interval := facet.Interval
+	if interval == "" {
+		interval = stores.Month
+	}
+	layout, exists := intervalLayouts[interval]
+	if !exists {
+		return nil, fmt.Errorf("%w: unknown interval %q of %s", stores.ErrInvalidFacet, interval, facet.Field)
+	}
+
+	counts, err := store.TermCounts(ctx, facet.Field, matches)
+	if err != nil {
+		return nil, err
+	}
+	out := []stores.Bucket{}
+	for term, count := range counts {
+		if _, err := time.Parse(layout, term); err == nil {
+			out = append(out, stores.Bucket{Key: term, Count: count})
+		}
+	}
+	slices.SortFunc(out, func(a, b stores.Bucket) int { return cmp.Compare(a.Key, b.Key) })
+	return out, nil
+}
+
+// summarise summarises the numbers in the field of the documents, every number of an extra field with several.
+func summarise(documentType, field string, matches []models.Model) (stores.Summary, error) {
+	if value, err := prototypes[documentType].ValueAt(field); err == nil {
+		if _, isInt := value.(int); !isInt {
+			return stores.Summary{}, fmt.Errorf("%w: %s %s is not a number", stores.ErrInvalidFacet, documentType, field)
+		}
+	}
+
+	out := stores.Summary{}
+	add := func(value any) {
+		var x float64
+		switch v := value.(type) {
+		case int:
+			x = float64(v)
+		// extra fields are decoded from json without a declared type
+		case float64:
+			x = v
+		default:
+			return
+		}
+		if out.Count == 0 {
+			out.Min, out.Max = x, x
+		}
+		out.Count++
+		out.Min, out.Max, out.Sum = min(out.Min, x), max(out.Max, x), out.Sum+x
+	}
+	for _, doc := range matches {
+		if values, several := models.FieldValue(doc, field).([]any); several {
+			for _, value := range values {
+				add(value)
+			}
+		} else {
+			add(models.FieldValue(doc, field))
+		}
+	}
+	if out.Count > 0 {
+		out.Mean = out.Sum / float64(out.Count)
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package implementations
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// gzipMagic are the first bytes of a gzipped file.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// dataFile is a data file open for reading, decompressed on the fly if it is gzipped,
+// whatever its extension.
+type dataFile struct {
+	io.Reader
+	f    *os.File
+	size int64
+	// compressed counts the bytes read from the file if it is gzipped, and is nil otherwise.
+	compressed *countingReader
+}
+
+// openDataFile opens the file at path, and decompresses it if it starts with the gzip magic bytes.
+func openDataFile(path string) (*dataFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	d := &dataFile{f: f, size: info.Size()}
+	counted := &countingReader{r: f}
+	buffered := bufio.NewReader(counted)
+	if magic, _ := buffered.Peek(len(gzipMagic)); !bytes.Equal(magic, gzipMagic) {
+		d.Reader = buffered
+		return d, nil
+	}
+	z, err := gzip.NewReader(buffered)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	d.Reader, d.compressed = z, counted
+	return d, nil
+}
+
+// read returns how much of the file has been read, given the offset reached in its decompressed contents.
+// For a gzipped file, that is the compressed bytes read so far, so it is never more than its size.
+func (d *dataFile) read(offset int64) int64 {
+	if d.compressed != nil {
+		return d.compressed.n
+	}
+	return offset
+}
+
+func (d *dataFile) Close() error {
+	return d.f.Close()
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,97 @@
+package implementations
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+)
+
+// sorted is a document with the values of the fields it is sorted by.
+type sorted struct {
+	doc    models.Model
+	values []any
+}
+
+// sortMatches sorts the documents by the sort, and then by their _id.
+func sortMatches(docs []models.Model, sort stores.Sort) []sorted {
+	out := make([]sorted, 0, len(docs))
+	for _, doc := range docs {
+		values := make([]any, 0, len(sort))
+		for _, key := range sort {
+			values = append(values, models.FieldValue(doc, key.Field))
+		}
+		out = append(out, sorted{doc: doc, values: values})
+	}
+	slices.SortFunc(out, func(a, b sorted) int {
+		return compareSorted(sort, a.values, a.doc.StringID(), b.values, b.doc.StringID())
+	})
+	return out
+}
+
+// compareSorted orders two documents by their values of the fields in the sort, and then by their _ids.
+func compareSorted(sort stores.Sort, aValues []any, aID string, bValues []any, bID string) int {
+	for i, key := range sort {
+		c := models.CompareValues(aValues[i], bValues[i])
+		if key.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareIDs(aID, bID)
+}
+
+// checkSort returns stores.ErrInvalidSort if the sort has a field that is not in fields.
+func checkSort(documentType string, sort stores.Sort, fields []string) error {
+	for _, key := range sort {
+		if !slices.Contains(fields, key.Field) {
+			return fmt.Errorf("%w: %s has no field %s", stores.ErrInvalidSort, documentType, key.Field)
+		}
+	}
+	return nil
+}
+
+// encodeSortValues encodes the values of the fields a document is sorted by, for a pageCursor.
+func encodeSortValues(values []any) ([]json.RawMessage, error) {
+	out := make([]json.RawMessage, 0, len(values))
+	for _, value := range values {
+		buf, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value %v: %w", value, err)
+		}
+		out = append(out, buf)
+	}
+	return out, nil
+}
+
+// decodeSortValues decodes the values encoded by encodeSortValues into the types of the fields of the document type,
+// so they compare with the values of the documents. The values of extra fields are decoded as encoding/json decodes into any.
+func decodeSortValues(documentType string, sort stores.Sort, raw []json.RawMessage) ([]any, error) {
+	if len(raw) != len(sort) {
+		return nil, fmt.Errorf("%w: the cursor is from another sort", stores.ErrInvalidPage)
+	}
+	prototype, exists := prototypes[documentType]
+	if !exists {
+		return nil, ErrInvalidDocType
+	}
+	out := make([]any, 0, len(raw))
+	for i, key := range sort {
+		var value any
+		if zero, err := prototype.ValueAt(key.Field); err == nil && zero != nil {
+			typed := reflect.New(reflect.TypeOf(zero))
+			if err := json.Unmarshal(raw[i], typed.Interface()); err != nil {
+				return nil, fmt.Errorf("%w: malformed cursor: %w", stores.ErrInvalidPage, err)
+			}
+			value = typed.Elem().Interface()
+		} else if err := json.Unmarshal(raw[i], &value); err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor: %w", stores.ErrInvalidPage, err)
+		}
+		out = append(out, value)
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package multi
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/satrap-illustrations/zs/internal/stores"
+)
+
+// mergeFacets adds up the facets computed by each source, in the order they were requested.
+// The buckets with the same key are counted together, and ordered as a source orders them.
+func mergeFacets(requested []stores.Facet, sources [][]stores.FacetResult) []stores.FacetResult {
+	if len(requested) == 0 {
+		return nil
+	}
+	out := make([]stores.FacetResult, 0, len(requested))
+	for i, facet := range requested {
+		merged := stores.FacetResult{Facet: facet}
+		counts := map[string]int{}
+		for _, results := range sources {
+			if i >= len(results) {
+				continue
+			}
+			for _, bucket := range results[i].Buckets {
+				counts[bucket.Key] += bucket.Count
+			}
+			merged.Summary = mergeSummaries(merged.Summary, results[i].Summary)
+		}
+
+		if facet.Kind != stores.Stats {
+			merged.Buckets = make([]stores.Bucket, 0, len(counts))
+			for key, count := range counts {
+				merged.Buckets = append(merged.Buckets, stores.Bucket{Key: key, Count: count})
+			}
+			slices.SortFunc(merged.Buckets, func(a, b stores.Bucket) int {
+				if facet.Kind == stores.Terms {
+					if c := cmp.Compare(b.Count, a.Count); c != 0 {
+						return c
+					}
+				}
+				return cmp.Compare(a.Key, b.Key)
+			})
+		}
+		out = append(out, merged)
+	}
+	return out
+}
+
+func mergeSummaries(a, b stores.Summary) stores.Summary {
+	switch {
+	case b.Count == 0:
+		return a
+	case a.Count == 0:
+		return b
+	}
+	out := stores.Summary{
+		Count: a.Count + b.Count,
+		Min:   min(a.Min, b.Min),
+		Max:   max(a.Max, b.Max),
+		Sum:   a.Sum + b.Sum,
+	}
+	out.Mean = out.Sum / float64(out.Count)
+	return out
+}
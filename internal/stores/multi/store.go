@@ -0,0 +1,519 @@
+// Package multi searches several stores together, such as the exports of different instances,
+// and tags each document with the name of the store it is from, so documents with the same _id are told apart.
+package multi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype"
+)
+
+var (
+	// ErrUnknownSource is returned when the source of a document or _id is not one of the Store, or cannot be told.
+	ErrUnknownSource = errors.New("unknown source")
+	// ErrDuplicateSource is returned when more than one source of a Store has the same name.
+	ErrDuplicateSource = errors.New("duplicate source")
+	// ErrNoSources is returned when a Store is made without any sources.
+	ErrNoSources = errors.New("no sources")
+)
+
+// Source is a store and the name its documents are tagged with.
+type Source struct {
+	Name  string
+	Store stores.Store
+}
+
+// tag wraps m in a models.SourcedModel from the source, inside the models.RelatedModel it may be wrapped in.
+func (s Source) tag(m models.Model) models.Model {
+	if related, ok := m.(*models.RelatedModel); ok {
+		return &models.RelatedModel{Model: s.tag(related.Model), Relation: related.Relation}
+	}
+	return &models.SourcedModel{Model: m, Source: s.Name}
+}
+
+func (s Source) tagAll(docs []models.Model) []models.Model {
+	out := make([]models.Model, 0, len(docs))
+	for _, doc := range docs {
+		out = append(out, s.tag(doc))
+	}
+	return out
+}
+
+func (s Source) tagHits(hits []stores.Hit) []stores.Hit {
+	out := make([]stores.Hit, 0, len(hits))
+	for _, hit := range hits {
+		related := make(map[string][]models.Model, len(hit.Related))
+		for relation, docs := range hit.Related {
+			related[relation] = s.tagAll(docs)
+		}
+		out = append(out, stores.Hit{Document: s.tag(hit.Document), MatchedFields: hit.MatchedFields, Related: related})
+	}
+	return out
+}
+
+// untag returns m without the models.SourcedModel it is wrapped in, keeping the models.RelatedModel it may be wrapped in.
+func untag(m models.Model) models.Model {
+	switch m := m.(type) {
+	case *models.RelatedModel:
+		return &models.RelatedModel{Model: untag(m.Model), Relation: m.Relation}
+	case *models.SourcedModel:
+		return m.Model
+	default:
+		return m
+	}
+}
+
+// Names returns the name of the source of each of dirs, its base name, or the whole path if the base name is not unique.
+func Names(dirs []string) []string {
+	count := map[string]int{}
+	for _, dir := range dirs {
+		count[filepath.Base(dir)]++
+	}
+	out := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if count[name] > 1 {
+			name = filepath.Clean(dir)
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// Load loads a store from each of dirs with load, in parallel, and returns them as sources named by Names.
+func Load(dirs []string, load func(dir string) (stores.Store, error)) ([]Source, error) {
+	names := Names(dirs)
+	sources := make([]Source, len(dirs))
+	errs := make([]error, len(dirs))
+	var wg sync.WaitGroup
+	for i, dir := range dirs {
+		wg.Add(1)
+		go func(i int, dir string) {
+			defer wg.Done()
+			store, err := load(dir)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to load %s: %w", dir, err)
+				return
+			}
+			sources[i] = Source{Name: names[i], Store: store}
+		}(i, dir)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+// Store is a stores.Store that searches each of its sources in parallel, and tags the documents found with their source.
+//
+// The documents of a search are those of each source in turn, and the pages of a search are merged in the order of
+// their sort, and then their _id. A field that only some sources have is searched in those, as the others have no
+// documents that match. A page cannot be sorted or faceted by such a field, though, as the documents of the others
+// would be left out, so that fails with stores.ErrInvalidSort or stores.ErrInvalidFacet, as it does in a single store.
+// Relations are followed in the source of the document they are followed from.
+// An _id can be qualified with the name of its source, as in "eu:101", to search for it, or delete it, in that source.
+type Store struct {
+	sources []Source
+}
+
+// New returns a Store of the sources, of which there must be at least one, with unique names.
+func New(sources ...Source) (*Store, error) {
+	if len(sources) == 0 {
+		return nil, ErrNoSources
+	}
+	names := map[string]bool{}
+	for _, source := range sources {
+		if names[source.Name] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSource, source.Name)
+		}
+		names[source.Name] = true
+	}
+	return &Store{sources: sources}, nil
+}
+
+// Sources returns the sources of the store.
+func (s *Store) Sources() []Source {
+	return slices.Clone(s.sources)
+}
+
+// source returns the source with the name.
+func (s *Store) source(name string) (Source, error) {
+	for _, source := range s.sources {
+		if source.Name == name {
+			return source, nil
+		}
+	}
+	return Source{}, fmt.Errorf("%w: %q", ErrUnknownSource, name)
+}
+
+// sourceOf returns the source doc is tagged with.
+func (s *Store) sourceOf(doc models.Model) (Source, error) {
+	name := models.SourceOf(doc)
+	if name == "" {
+		return Source{}, fmt.Errorf("%w: %s %s is not tagged with a source", ErrUnknownSource, doc.DocumentType(), doc.StringID())
+	}
+	return s.source(name)
+}
+
+// searched returns the sources to search the field of for query, and the query to search them for,
+// which is only the source an _id is qualified with.
+func (s *Store) searched(field, query string) ([]Source, string) {
+	if field == "_id" {
+		if name, id, qualified := strings.Cut(query, ":"); qualified {
+			if source, err := s.source(name); err == nil {
+				return []Source{source}, id
+			}
+		}
+	}
+	return s.sources, query
+}
+
+// result is what a source returned.
+type result[T any] struct {
+	source Source
+	value  T
+}
+
+// fanOut calls search with the store of each source in parallel, and returns what each returned, in the order of the sources.
+// A source without the field searched is left out, unless every source is. Any other error, including a sort or facet
+// of a field a source does not have, stops the rest, and is returned.
+func fanOut[T any](
+	ctx context.Context,
+	sources []Source,
+	search func(ctx context.Context, source Source) (T, error),
+) ([]result[T], error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	values := make([]T, len(sources))
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source Source) {
+			defer wg.Done()
+			values[i], errs[i] = search(ctx, source)
+			if errs[i] != nil && !errors.Is(errs[i], doctype.ErrInvalidField) {
+				cancel()
+			}
+		}(i, source)
+	}
+	wg.Wait()
+
+	out := make([]result[T], 0, len(sources))
+	var missing, failed error
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			out = append(out, result[T]{source: sources[i], value: values[i]})
+		case errors.Is(err, doctype.ErrInvalidField):
+			missing = err
+		// a search stopped by the error of another is not why the search failed.
+		case failed == nil || errors.Is(failed, context.Canceled):
+			failed = fmt.Errorf("source %s: %w", sources[i].Name, err)
+		}
+	}
+	if failed != nil {
+		return nil, failed
+	}
+	if len(out) == 0 && missing != nil {
+		return nil, missing
+	}
+	return out, nil
+}
+
+func (s *Store) ListDocumentTypes() []string {
+	return s.sources[0].Store.ListDocumentTypes()
+}
+
+// ListFields returns the fields of each document type in any source, in the order of the first source with each.
+func (s *Store) ListFields() map[string][]string {
+	out := map[string][]string{}
+	for _, source := range s.sources {
+		for docType, fields := range source.Store.ListFields() {
+			for _, field := range fields {
+				if !slices.Contains(out[docType], field) {
+					out[docType] = append(out[docType], field)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (s *Store) ListValues(documentType, field string) []string {
+	return s.sources[0].Store.ListValues(documentType, field)
+}
+
+func (s *Store) Search(documentType, field, query string) ([]stores.Hit, error) {
+	return s.SearchContext(context.Background(), documentType, field, query)
+}
+
+func (s *Store) SearchContext(ctx context.Context, documentType, field, query string) ([]stores.Hit, error) {
+	sources, query := s.searched(field, query)
+	results, err := fanOut(ctx, sources, func(ctx context.Context, source Source) ([]stores.Hit, error) {
+		return source.Store.SearchContext(ctx, documentType, field, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := []stores.Hit{}
+	for _, r := range results {
+		out = append(out, r.source.tagHits(r.value)...)
+	}
+	return out, nil
+}
+
+// SearchPage requests a page of each source, continuing from where the last page left it, and merges them.
+// The cursor of the next page has the cursor of the page of each source it continues in,
+// and how many of its documents were returned, as the merged page may end in the middle of the page of a source.
+// Each source is read a page at a time, of at least the documents of the merged page, and the page after if the rest of
+// the one it continues in is too short, so reading each merged page takes about as long as reading the first.
+func (s *Store) SearchPage(
+	ctx context.Context,
+	documentType, field, query string,
+	page stores.PageRequest,
+) (stores.Page, error) {
+	if page.Limit < 0 || page.Offset < 0 {
+		return stores.Page{}, fmt.Errorf("%w: limit %d and offset %d", stores.ErrInvalidPage, page.Limit, page.Offset)
+	}
+	search := pageCursor{DocumentType: documentType, Field: field, Query: query, Sort: page.Sort.String()}
+	positions := map[string]position{}
+	offset := page.Offset
+	if page.Cursor != "" {
+		c, err := decodePageCursor(page.Cursor)
+		if err != nil {
+			return stores.Page{}, err
+		}
+		if c.DocumentType != documentType || c.Field != field || c.Query != query {
+			return stores.Page{}, fmt.Errorf("%w: the cursor is from another search", stores.ErrInvalidPage)
+		}
+		if c.Sort != search.Sort {
+			return stores.Page{}, fmt.Errorf("%w: the cursor is from another sort", stores.ErrInvalidPage)
+		}
+		positions, offset = c.Sources, 0
+	}
+
+	sources, query := s.searched(field, query)
+	results, err := fanOut(ctx, sources, func(ctx context.Context, source Source) ([]stores.Page, error) {
+		at := positions[source.Name]
+		request := stores.PageRequest{Cursor: at.Cursor, Sort: page.Sort, Facets: page.Facets}
+		switch {
+		case at.Done:
+			// the documents of a source are all returned, but they are still counted.
+			request.Limit = 1
+		case page.Limit > 0:
+			request.Limit = max(offset+page.Limit, at.Skip+1)
+		}
+		first, err := source.Store.SearchPage(ctx, documentType, field, query, request)
+		if err != nil {
+			return nil, err
+		}
+		pages := []stores.Page{first}
+		// as Skip is less than the limit, the rest of the first page and the next have enough documents.
+		if !at.Done && len(first.Hits)-at.Skip < offset+page.Limit && first.NextCursor != "" {
+			request.Cursor, request.Facets = first.NextCursor, nil
+			next, err := source.Store.SearchPage(ctx, documentType, field, query, request)
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, next)
+		}
+		return pages, nil
+	})
+	if err != nil {
+		return stores.Page{}, err
+	}
+
+	out := stores.Page{}
+	facets := make([][]stores.FacetResult, 0, len(results))
+	// unread are the hits of each source that have not been returned yet.
+	unread := make([][]stores.Hit, len(results))
+	for i, r := range results {
+		first := r.value[0]
+		out.Total += first.Total
+		facets = append(facets, first.Facets)
+		if at := positions[r.source.Name]; !at.Done {
+			unread[i] = slices.Clone(first.Hits[min(at.Skip, len(first.Hits)):])
+			for _, next := range r.value[1:] {
+				unread[i] = append(unread[i], next.Hits...)
+			}
+		}
+	}
+	out.Facets = mergeFacets(page.Facets, facets)
+
+	read := make([]int, len(results))
+	for page.Limit == 0 || len(out.Hits) < offset+page.Limit {
+		next := -1
+		for i := range unread {
+			if len(unread[i]) > 0 && (next < 0 || compareHits(page.Sort, unread[i][0], unread[next][0]) < 0) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		out.Hits = append(out.Hits, results[next].source.tagHits(unread[next][:1])...)
+		unread[next] = unread[next][1:]
+		read[next]++
+	}
+	out.Hits = out.Hits[min(offset, len(out.Hits)):]
+
+	search.Sources = map[string]position{}
+	more := false
+	for i, r := range results {
+		at := positions[r.source.Name]
+		if !at.Done {
+			at = at.after(min(at.Skip, len(r.value[0].Hits))+read[i], r.value)
+			more = more || !at.Done
+		}
+		search.Sources[r.source.Name] = at
+	}
+	if more && len(out.Hits) > 0 {
+		out.NextCursor = search.encode()
+	}
+	return out, nil
+}
+
+// compareHits orders hits by the values of the fields in the sort, and then by their _id, as each source orders them.
+func compareHits(sort stores.Sort, a, b stores.Hit) int {
+	for _, key := range sort {
+		c := models.CompareValues(models.FieldValue(a.Document, key.Field), models.FieldValue(b.Document, key.Field))
+		if key.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return models.CompareValues(models.FieldValue(a.Document, "_id"), models.FieldValue(b.Document, "_id"))
+}
+
+func (s *Store) Related(doc models.Model, path ...string) ([]models.Model, error) {
+	return s.RelatedContext(context.Background(), doc, path...)
+}
+
+func (s *Store) RelatedContext(ctx context.Context, doc models.Model, path ...string) ([]models.Model, error) {
+	source, err := s.sourceOf(doc)
+	if err != nil {
+		return nil, err
+	}
+	related, err := source.Store.RelatedContext(ctx, untag(doc), path...)
+	if err != nil {
+		return nil, err
+	}
+	return source.tagAll(related), nil
+}
+
+func (s *Store) Walk(doc models.Model, depth int) ([]models.Model, error) {
+	return s.WalkContext(context.Background(), doc, depth)
+}
+
+func (s *Store) WalkContext(ctx context.Context, doc models.Model, depth int) ([]models.Model, error) {
+	source, err := s.sourceOf(doc)
+	if err != nil {
+		return nil, err
+	}
+	walked, err := source.Store.WalkContext(ctx, untag(doc), depth)
+	if err != nil {
+		return nil, err
+	}
+	return source.tagAll(walked), nil
+}
+
+// Upsert upserts doc into the source it is tagged with.
+func (s *Store) Upsert(doc models.Model) error {
+	source, err := s.sourceOf(doc)
+	if err != nil {
+		return err
+	}
+	return source.Store.Upsert(untag(doc))
+}
+
+// Delete deletes the document from the source its _id is qualified with.
+func (s *Store) Delete(documentType, id string) error {
+	name, id, qualified := strings.Cut(id, ":")
+	if !qualified {
+		return fmt.Errorf("%w: qualify the _id %s with the name of its source, as in %s:%s", ErrUnknownSource, name, s.sources[0].Name, name)
+	}
+	source, err := s.source(name)
+	if err != nil {
+		return err
+	}
+	return source.Store.Delete(documentType, id)
+}
+
+// Version is the sum of the versions of the sources, so it increases when any of them does. See stores.Versioned.
+func (s *Store) Version() uint64 {
+	version := uint64(0)
+	for _, source := range s.sources {
+		if versioned, ok := source.Store.(stores.Versioned); ok {
+			version += versioned.Version()
+		}
+	}
+	return version
+}
+
+// pageCursor is the position after the last document of a page of a search in each source, which Page.NextCursor encodes.
+type pageCursor struct {
+	DocumentType string `json:"t"`
+	Field        string `json:"f"`
+	Query        string `json:"q"`
+	// Sort is the sort of the search, as stores.Sort.String formats it.
+	Sort string `json:"s,omitempty"`
+	// Sources are the positions in each source, by name.
+	Sources map[string]position `json:"p"`
+}
+
+// position is where the next page of a source starts: Skip documents into the page of the source from Cursor,
+// or nowhere if every document of the source is Done.
+type position struct {
+	Cursor string `json:"c,omitempty"`
+	Skip   int    `json:"k,omitempty"`
+	Done   bool   `json:"d,omitempty"`
+}
+
+// after returns the position after the first n documents of pages, which were read in turn from p.
+// It moves to the cursor of the next page once the documents of a page are all read, so Skip stays within a page.
+func (p position) after(n int, pages []stores.Page) position {
+	cursor := p.Cursor
+	for _, page := range pages {
+		if n < len(page.Hits) {
+			return position{Cursor: cursor, Skip: n}
+		}
+		if page.NextCursor == "" {
+			return position{Done: true}
+		}
+		n -= len(page.Hits)
+		cursor = page.NextCursor
+	}
+	return position{Cursor: cursor, Skip: n}
+}
+
+func (c pageCursor) encode() string {
+	buf, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func decodePageCursor(s string) (pageCursor, error) {
+	c := pageCursor{}
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(buf, &c)
+	}
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("%w: malformed cursor: %w", stores.ErrInvalidPage, err)
+	}
+	return c, nil
+}
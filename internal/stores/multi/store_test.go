@@ -0,0 +1,283 @@
+package multi_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/satrap-illustrations/zs/internal/stores/multi"
+	"gotest.tools/v3/assert"
+)
+
+// newStore returns a store of two sources with the same data, so every _id is in both.
+func newStore(t *testing.T) *multi.Store {
+	t.Helper()
+
+	sources, err := multi.Load([]string{"../../../data", "../../../data"}, func(dir string) (stores.Store, error) {
+		return implementations.NewInvertedStore(dir)
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(sources))
+	sources[0].Name, sources[1].Name = "eu", "us"
+	s, err := multi.New(sources...)
+	assert.NilError(t, err)
+	return s
+}
+
+func sourcesOf(docs []models.Model) []string {
+	out := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		out = append(out, models.SourceOf(doc))
+	}
+	return out
+}
+
+func TestNames(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		dirs     []string
+		expected []string
+	}{
+		{name: "base names", dirs: []string{"exports/eu", "exports/us/"}, expected: []string{"eu", "us"}},
+		{name: "same base names", dirs: []string{"eu/data", "us/data", "sandbox"}, expected: []string{"eu/data", "us/data", "sandbox"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.DeepEqual(t, tc.expected, multi.Names(tc.dirs))
+		})
+	}
+}
+
+func TestNewAndLoad(t *testing.T) {
+	t.Parallel()
+
+	_, err := multi.New(multi.Source{Name: "eu"}, multi.Source{Name: "eu"})
+	assert.ErrorIs(t, err, multi.ErrDuplicateSource)
+
+	_, err = multi.New()
+	assert.ErrorIs(t, err, multi.ErrNoSources)
+
+	_, err = multi.Load([]string{"../../../data", filepath.Join(t.TempDir(), "missing")}, func(dir string) (stores.Store, error) {
+		return implementations.NewInvertedStore(dir)
+	})
+	assert.ErrorContains(t, err, "missing")
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	s := newStore(t)
+
+	// the same _id is found in each source, tagged with it
+	hits, err := s.Search("Organizations", "_id", "101")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"eu", "us"}, sourcesOf(stores.Documents(hits)))
+	for _, hit := range hits {
+		for _, related := range hit.Related {
+			for _, doc := range related {
+				assert.Equal(t, models.SourceOf(hit.Document), models.SourceOf(doc))
+			}
+		}
+	}
+
+	// unless it is qualified with the source
+	hits, err = s.Search("Organizations", "_id", "us:101")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"us"}, sourcesOf(stores.Documents(hits)))
+	assert.Equal(t, "101", hits[0].Document.StringID())
+
+	found, err := s.SearchContext(context.Background(), "Tickets", "organization_id", "118")
+	assert.NilError(t, err)
+	assert.Equal(t, 22, len(found))
+
+	_, err = s.Search("Tickets", "missing", "118")
+	assert.ErrorContains(t, err, "invalid field")
+}
+
+func TestSearchFieldOfSomeSources(t *testing.T) {
+	t.Parallel()
+
+	s := newStore(t)
+	found, err := stores.Find(s, "Organizations", "us:101")
+	assert.NilError(t, err)
+	organization := *found.(*models.SourcedModel).Model.(*models.Organization)
+	organization.Extras = models.Extras{"region": "US"}
+	assert.NilError(t, s.Upsert(&models.SourcedModel{Model: &organization, Source: "us"}))
+
+	assert.Assert(t, len(s.ListFields()["Organizations"]) > len(models.FieldSlice(&models.Organization{})))
+	hits, err := s.Search("Organizations", "region", "US")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"us"}, sourcesOf(stores.Documents(hits)))
+
+	// but the documents of the other source would be left out of a sort or facet of the field, so those fail
+	sort, err := stores.ParseSort("region asc")
+	assert.NilError(t, err)
+	_, err = s.SearchPage(context.Background(), "Organizations", "_id", "101", stores.PageRequest{Sort: sort})
+	assert.ErrorIs(t, err, stores.ErrInvalidSort)
+	_, err = s.SearchPage(context.Background(), "Organizations", "_id", "101", stores.PageRequest{
+		Facets: []stores.Facet{{Field: "region", Kind: stores.Terms}},
+	})
+	assert.ErrorIs(t, err, stores.ErrInvalidFacet)
+}
+
+func TestRelatedAndChanges(t *testing.T) {
+	t.Parallel()
+
+	s := newStore(t)
+	eu, err := stores.Find(s, "Organizations", "eu:101")
+	assert.NilError(t, err)
+	us, err := stores.Find(s, "Organizations", "us:101")
+	assert.NilError(t, err)
+
+	// relations are followed in the source of the document
+	related, err := s.Related(us, "tickets")
+	assert.NilError(t, err)
+	assert.Assert(t, len(related) > 0)
+	for _, source := range sourcesOf(related) {
+		assert.Equal(t, "us", source)
+	}
+	walked, err := s.Walk(related[0], 1)
+	assert.NilError(t, err)
+	for _, source := range sourcesOf(walked) {
+		assert.Equal(t, "us", source)
+	}
+
+	_, err = s.Related(&models.Organization{ID: 101}, "tickets")
+	assert.ErrorIs(t, err, multi.ErrUnknownSource)
+
+	// and documents are changed in their source
+	renamed := *us.(*models.SourcedModel).Model.(*models.Organization)
+	renamed.Name = "Renamed"
+	assert.NilError(t, s.Upsert(&models.SourcedModel{Model: &renamed, Source: "us"}))
+	found, err := stores.Find(s, "Organizations", "us:101")
+	assert.NilError(t, err)
+	assert.Equal(t, "Renamed", found.(*models.SourcedModel).Model.(*models.Organization).Name)
+	found, err = stores.Find(s, "Organizations", "eu:101")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, eu, found)
+
+	assert.ErrorIs(t, s.Upsert(&renamed), multi.ErrUnknownSource)
+	assert.ErrorIs(t, s.Delete("Organizations", "101"), multi.ErrUnknownSource)
+	assert.NilError(t, s.Delete("Organizations", "eu:101"))
+	hits, err := s.Search("Organizations", "_id", "101")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"us"}, sourcesOf(stores.Documents(hits)))
+}
+
+func TestSearchPage(t *testing.T) {
+	t.Parallel()
+
+	s := newStore(t)
+	ctx := context.Background()
+	sort, err := stores.ParseSort("priority asc, created_at desc")
+	assert.NilError(t, err)
+	facets := []stores.Facet{{Field: "priority", Kind: stores.Terms}, {Field: "created_at", Kind: stores.DateHistogram}}
+
+	all, err := s.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Sort: sort, Facets: facets})
+	assert.NilError(t, err)
+	assert.Equal(t, all.Total, len(all.Hits))
+	assert.Equal(t, "", all.NextCursor)
+
+	// the documents of every source are counted
+	unsorted, err := s.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Facets: facets[:1]})
+	assert.NilError(t, err)
+	assert.Equal(t, all.Total, unsorted.Total)
+	single, err := implementations.NewInvertedStore("../../../data")
+	assert.NilError(t, err)
+	one, err := single.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Facets: facets})
+	assert.NilError(t, err)
+	assert.Equal(t, 2*one.Total, all.Total)
+	for i, facet := range one.Facets {
+		for j, bucket := range facet.Buckets {
+			assert.Equal(t, bucket.Key, all.Facets[i].Buckets[j].Key)
+			assert.Equal(t, 2*bucket.Count, all.Facets[i].Buckets[j].Count)
+		}
+	}
+
+	// the pages follow each other in the order of the sort, whichever source they are from
+	paged := []stores.Hit{}
+	page := stores.PageRequest{Limit: 7, Sort: sort}
+	for {
+		found, err := s.SearchPage(ctx, "Tickets", "status", "pending", page)
+		assert.NilError(t, err)
+		assert.Equal(t, all.Total, found.Total)
+		paged = append(paged, found.Hits...)
+		if found.NextCursor == "" {
+			break
+		}
+		page.Cursor = found.NextCursor
+	}
+	assert.DeepEqual(t, stores.Documents(all.Hits), stores.Documents(paged))
+	for i := 1; i < len(paged); i++ {
+		a, b := paged[i-1].Document, paged[i].Document
+		c := models.CompareValues(models.FieldValue(a, "priority"), models.FieldValue(b, "priority"))
+		assert.Assert(t, c <= 0)
+	}
+
+	// as do offsets
+	offset, err := s.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Limit: 5, Offset: 9, Sort: sort})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stores.Documents(all.Hits[9:14]), stores.Documents(offset.Hits))
+
+	_, err = s.SearchPage(ctx, "Tickets", "status", "open", stores.PageRequest{Cursor: page.Cursor, Sort: sort})
+	assert.ErrorIs(t, err, stores.ErrInvalidPage)
+	_, err = s.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Cursor: page.Cursor})
+	assert.ErrorIs(t, err, stores.ErrInvalidPage)
+}
+
+// countingStore counts the documents that match on the pages it returns.
+type countingStore struct {
+	stores.Store
+	read *atomic.Int64
+}
+
+func (s countingStore) SearchPage(
+	ctx context.Context,
+	documentType, field, query string,
+	page stores.PageRequest,
+) (stores.Page, error) {
+	found, err := s.Store.SearchPage(ctx, documentType, field, query, page)
+	s.read.Add(int64(len(found.Hits)))
+	return found, err
+}
+
+func TestSearchPageReadsEachSourcePageOnce(t *testing.T) {
+	t.Parallel()
+
+	read := &atomic.Int64{}
+	sources := newStore(t).Sources()
+	for i := range sources {
+		sources[i].Store = countingStore{Store: sources[i].Store, read: read}
+	}
+	s, err := multi.New(sources...)
+	assert.NilError(t, err)
+	sort, err := stores.ParseSort("priority asc")
+	assert.NilError(t, err)
+
+	// a merged page reads at most two pages of its size from each source, however far into the search it is
+	const limit = 3
+	pages, total := 0, 0
+	page := stores.PageRequest{Limit: limit, Sort: sort}
+	for {
+		found, err := s.SearchPage(context.Background(), "Tickets", "status", "pending", page)
+		assert.NilError(t, err)
+		pages++
+		total = found.Total
+		if found.NextCursor == "" {
+			break
+		}
+		page.Cursor = found.NextCursor
+	}
+	assert.Assert(t, pages > 10)
+	assert.Assert(t, read.Load() <= int64(pages*2*2*limit), "%d documents read for %d pages of %d", read.Load(), pages, total)
+}
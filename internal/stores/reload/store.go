@@ -0,0 +1,223 @@
+// Package reload reloads a store when the files in its data directory change.
+package reload
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+)
+
+// DefaultDelay is how long the data directory must be left unchanged before the store is reloaded,
+// so that the files written together by an export are loaded together.
+const DefaultDelay = 500 * time.Millisecond
+
+// Option configures how a Store reloads.
+type Option func(*Store)
+
+// WithDelay sets how long the data directory must be left unchanged before the store is reloaded.
+func WithDelay(delay time.Duration) Option {
+	return func(s *Store) {
+		s.delay = delay
+	}
+}
+
+// Event is the outcome of a reload.
+type Event struct {
+	// Err is why the store could not be reloaded, in which case the old store is kept, or nil if it was replaced.
+	Err error
+}
+
+// Store is a stores.Store that is rebuilt in the background when the files in its data directory change.
+// The new store replaces the old one atomically once it has loaded, so each call sees either the old or the new data.
+// If the new files fail to load, the old store is kept.
+// Changes made with Upsert and Delete are to the current store, so they are lost when it is reloaded.
+type Store struct {
+	current atomic.Pointer[loaded]
+	load    func() (stores.Store, error)
+	delay   time.Duration
+	watcher *fsnotify.Watcher
+	events  chan Event
+
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// loaded boxes a stores.Store, so it can be swapped atomically.
+type loaded struct {
+	stores.Store
+	// reloads is the number of times the store had been reloaded when it was loaded.
+	reloads uint64
+}
+
+// version is the number of reloads in the high 32 bits, and the version of the store in the low 32 bits,
+// so it keeps increasing when the store is replaced by one whose version starts again.
+func (l *loaded) version() uint64 {
+	version := l.reloads << 32
+	if versioned, ok := l.Store.(stores.Versioned); ok {
+		version |= versioned.Version() & (1<<32 - 1)
+	}
+	return version
+}
+
+// Watch watches the directory dir, and replaces store with the store returned by load whenever the files in it change.
+func Watch(dir string, store stores.Store, load func() (stores.Store, error), opts ...Option) (*Store, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	s := &Store{
+		load:    load,
+		delay:   DefaultDelay,
+		watcher: watcher,
+		events:  make(chan Event, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.current.Store(&loaded{Store: store})
+
+	go s.watch()
+	return s, nil
+}
+
+// Events returns the outcome of each reload. Only the latest outcome is kept until it is received,
+// so a slow receiver misses the ones before it. The channel is closed by Close.
+func (s *Store) Events() <-chan Event {
+	return s.events
+}
+
+// Close stops watching the data directory. The current store is kept.
+func (s *Store) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		<-s.stopped
+		err = s.watcher.Close()
+	})
+	return err
+}
+
+// watch reloads the store once the data directory has been left unchanged for the delay after a change.
+func (s *Store) watch() {
+	defer close(s.stopped)
+	defer close(s.events)
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-s.done:
+			return
+		case event, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			reload = time.After(s.delay)
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			s.notify(Event{Err: fmt.Errorf("failed to watch the data directory: %w", err)})
+		case <-reload:
+			reload = nil
+			s.reload()
+		}
+	}
+}
+
+func (s *Store) reload() {
+	store, err := s.load()
+	if err != nil {
+		s.notify(Event{Err: err})
+		return
+	}
+	s.current.Store(&loaded{Store: store, reloads: s.current.Load().reloads + 1})
+	s.notify(Event{})
+}
+
+// notify sends e, replacing the event that has not been received yet, if any.
+func (s *Store) notify(e Event) {
+	select {
+	case <-s.events:
+	default:
+	}
+	s.events <- e
+}
+
+func (s *Store) store() stores.Store {
+	return s.current.Load().Store
+}
+
+// Version increases when the store is reloaded, and when the documents of the current store change.
+// See stores.Versioned.
+func (s *Store) Version() uint64 {
+	return s.current.Load().version()
+}
+
+func (s *Store) ListDocumentTypes() []string {
+	return s.store().ListDocumentTypes()
+}
+
+func (s *Store) ListFields() map[string][]string {
+	return s.store().ListFields()
+}
+
+func (s *Store) ListValues(documentType, field string) []string {
+	return s.store().ListValues(documentType, field)
+}
+
+func (s *Store) Search(documentType, field, query string) ([]stores.Hit, error) {
+	return s.store().Search(documentType, field, query)
+}
+
+func (s *Store) SearchContext(ctx context.Context, documentType, field, query string) ([]stores.Hit, error) {
+	return s.store().SearchContext(ctx, documentType, field, query)
+}
+
+func (s *Store) SearchPage(
+	ctx context.Context,
+	documentType, field, query string,
+	page stores.PageRequest,
+) (stores.Page, error) {
+	return s.store().SearchPage(ctx, documentType, field, query, page)
+}
+
+func (s *Store) Related(doc models.Model, path ...string) ([]models.Model, error) {
+	return s.store().Related(doc, path...)
+}
+
+func (s *Store) RelatedContext(ctx context.Context, doc models.Model, path ...string) ([]models.Model, error) {
+	return s.store().RelatedContext(ctx, doc, path...)
+}
+
+func (s *Store) Walk(doc models.Model, depth int) ([]models.Model, error) {
+	return s.store().Walk(doc, depth)
+}
+
+func (s *Store) WalkContext(ctx context.Context, doc models.Model, depth int) ([]models.Model, error) {
+	return s.store().WalkContext(ctx, doc, depth)
+}
+
+func (s *Store) Upsert(doc models.Model) error {
+	return s.store().Upsert(doc)
+}
+
+func (s *Store) Delete(documentType, id string) error {
+	return s.store().Delete(documentType, id)
+}
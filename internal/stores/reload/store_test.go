@@ -0,0 +1,100 @@
+package reload_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/satrap-illustrations/zs/internal/stores/reload"
+	"gotest.tools/v3/assert"
+)
+
+// copyData copies the data files into a new directory, so they can be changed.
+func copyData(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, name := range []string{"organizations.json", "tickets.json", "users.json"} {
+		buf, err := os.ReadFile(filepath.Join("../../../data", name))
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), buf, 0o600))
+	}
+	return dir
+}
+
+// replaceInFile replaces old with new in the file, as an export would, by renaming a new file over it.
+func replaceInFile(t *testing.T, path, old, new string) {
+	t.Helper()
+
+	buf, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(path+".tmp", []byte(strings.ReplaceAll(string(buf), old, new)), 0o600))
+	assert.NilError(t, os.Rename(path+".tmp", path))
+}
+
+func nextEvent(t *testing.T, s *reload.Store) reload.Event {
+	t.Helper()
+
+	select {
+	case e, ok := <-s.Events():
+		assert.Assert(t, ok)
+		return e
+	case <-time.After(10 * time.Second):
+		t.Fatal("the store was not reloaded")
+		return reload.Event{}
+	}
+}
+
+func organizationName(t *testing.T, s stores.Store, id string) string {
+	t.Helper()
+
+	doc, err := stores.Find(s, "Organizations", id)
+	assert.NilError(t, err)
+	organization, ok := doc.(*models.Organization)
+	assert.Assert(t, ok)
+	return organization.Name
+}
+
+func TestReload(t *testing.T) {
+	t.Parallel()
+
+	dir := copyData(t)
+	load := func() (stores.Store, error) { return implementations.NewInvertedStore(dir) }
+	initial, err := load()
+	assert.NilError(t, err)
+
+	s, err := reload.Watch(dir, initial, load, reload.WithDelay(100*time.Millisecond))
+	assert.NilError(t, err)
+	defer s.Close()
+
+	assert.Equal(t, "Enthaze", organizationName(t, s, "101"))
+
+	// the new data replaces the old
+	replaceInFile(t, filepath.Join(dir, "organizations.json"), `"Enthaze"`, `"Enthazed"`)
+	assert.NilError(t, nextEvent(t, s).Err)
+
+	assert.Equal(t, "Enthazed", organizationName(t, s, "101"))
+
+	// and data that fails to load is not used
+	replaceInFile(t, filepath.Join(dir, "organizations.json"), `"Enthazed"`, `"Enthazed`)
+	assert.Assert(t, nextEvent(t, s).Err != nil)
+
+	assert.Equal(t, "Enthazed", organizationName(t, s, "101"))
+
+	assert.NilError(t, s.Close())
+	_, open := <-s.Events()
+	assert.Assert(t, !open)
+	assert.NilError(t, s.Close())
+}
+
+func TestWatchMissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	_, err := reload.Watch(filepath.Join(t.TempDir(), "missing"), nil, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
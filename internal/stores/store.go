@@ -1,9 +1,220 @@
 package stores
 
-import "github.com/satrap-illustrations/zs/internal/models"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/satrap-illustrations/zs/internal/models"
+)
+
+var (
+	ErrDocumentNotFound = errors.New("document not found")
+	ErrInvalidPage      = errors.New("invalid page")
+	ErrInvalidSort      = errors.New("invalid sort")
+)
+
+// Hit is a document that matches a search, with the documents related to it.
+type Hit struct {
+	Document models.Model
+	// MatchedFields are the fields of Document that matched the query.
+	MatchedFields []string
+	// Related are the documents reached by each relation from Document, each once, in the order the relation returns them.
+	// Relations that reach no documents are left out.
+	Related map[string][]models.Model
+}
+
+// NewHit returns the Hit of doc, which matched the fields, with the related documents,
+// each a models.RelatedModel labelled with its relation, as returned by graph.Traverser.Neighbours.
+// A document reached more than once by the same relation is only listed once.
+func NewHit(doc models.Model, matchedFields []string, related []models.Model) Hit {
+	hit := Hit{Document: doc, MatchedFields: matchedFields, Related: map[string][]models.Model{}}
+	seen := map[string]map[string]bool{}
+	for _, m := range related {
+		r, ok := m.(*models.RelatedModel)
+		if !ok {
+			continue
+		}
+		if seen[r.Relation] == nil {
+			seen[r.Relation] = map[string]bool{}
+		}
+		if seen[r.Relation][r.StringID()] {
+			continue
+		}
+		seen[r.Relation][r.StringID()] = true
+		hit.Related[r.Relation] = append(hit.Related[r.Relation], r.Model)
+	}
+	return hit
+}
+
+// Models returns the document followed by the documents related to it,
+// each wrapped in a models.RelatedModel labelled with its relation, in the order of the relation names.
+func (h Hit) Models() []models.Model {
+	relations := make([]string, 0, len(h.Related))
+	for relation := range h.Related {
+		relations = append(relations, relation)
+	}
+	slices.Sort(relations)
+
+	out := []models.Model{h.Document}
+	for _, relation := range relations {
+		out = append(out, models.Relate(relation, h.Related[relation])...)
+	}
+	return out
+}
+
+// Documents returns the documents of the hits, without the documents related to them.
+func Documents(hits []Hit) []models.Model {
+	out := make([]models.Model, 0, len(hits))
+	for _, hit := range hits {
+		out = append(out, hit.Document)
+	}
+	return out
+}
+
+// SortKey orders documents by the value of a field, declared, computed or extra.
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// Sort orders documents by each key in turn, and then by their _id.
+// Values are compared by their type, see models.CompareValues.
+type Sort []SortKey
+
+// ParseSort parses a sort specification, a comma separated list of fields each optionally followed by asc or desc,
+// e.g. "created_at desc, priority asc". It may start with "sort=". Fields are ascending by default.
+func ParseSort(spec string) (Sort, error) {
+	spec = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(spec), "sort="))
+	if spec == "" {
+		return nil, nil
+	}
+	out := Sort{}
+	for _, key := range strings.Split(spec, ",") {
+		words := strings.Fields(key)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("%w: expected a field and asc or desc, got %q", ErrInvalidSort, strings.TrimSpace(key))
+		}
+		sortKey := SortKey{Field: words[0]}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				sortKey.Descending = true
+			default:
+				return nil, fmt.Errorf("%w: expected asc or desc after %s, got %q", ErrInvalidSort, words[0], words[1])
+			}
+		}
+		out = append(out, sortKey)
+	}
+	return out, nil
+}
+
+// String returns the sort in the form ParseSort parses, with the direction of every field.
+func (s Sort) String() string {
+	keys := make([]string, 0, len(s))
+	for _, key := range s {
+		direction := "asc"
+		if key.Descending {
+			direction = "desc"
+		}
+		keys = append(keys, key.Field+" "+direction)
+	}
+	return strings.Join(keys, ", ")
+}
+
+// PageRequest selects a page of the documents that match a search.
+type PageRequest struct {
+	// Limit is the most documents that match to return, or 0 for all of them.
+	Limit int
+	// Offset is the number of documents that match to skip. It is ignored with a Cursor.
+	Offset int
+	// Cursor continues after the page it was returned with, as Page.NextCursor.
+	Cursor string
+	// Sort orders the documents that match. They are in the order of their _id without it.
+	Sort Sort
+	// Facets are the aggregations to compute over every document that matches, not only those on the page.
+	Facets []Facet
+}
+
+// Page is a page of the documents that match a search, in the order of the Sort requested.
+type Page struct {
+	// Hits are the documents on the page that match, with the documents related to them.
+	Hits []Hit
+	// Total is the number of documents that match, on every page.
+	Total int
+	// NextCursor requests the next page, or is empty if this is the last page.
+	// It continues after the last document of this page, even if documents are added or deleted in between.
+	NextCursor string
+	// Facets are the aggregations requested, in the order they were requested.
+	Facets []FacetResult
+}
 
 type Store interface {
 	ListDocumentTypes() []string
 	ListFields() map[string][]string
-	Search(documentType, field, query string) ([]models.Model, error)
+
+	// ListValues returns the valid values of an enumerated field, or nil if any value is valid.
+	ListValues(documentType, field string) []string
+
+	// Search returns a Hit for each document of the type whose field matches query, with the documents related to it.
+	Search(documentType, field, query string) ([]Hit, error)
+
+	// SearchContext searches like Search, and stops with the error of ctx,
+	// context.Canceled or context.DeadlineExceeded, once ctx is done.
+	SearchContext(ctx context.Context, documentType, field, query string) ([]Hit, error)
+
+	// SearchPage searches like SearchContext, and returns the page of the documents that match selected by page.
+	// Only the documents on the page are followed by the documents related to them.
+	// It returns ErrInvalidPage if the page has a negative limit or offset, or a cursor from another search or sort,
+	// and ErrInvalidSort or ErrInvalidFacet if the sort or a facet has a field the document type does not have.
+	SearchPage(ctx context.Context, documentType, field, query string, page PageRequest) (Page, error)
+
+	// Related follows the named relations from doc, one hop per name,
+	// and returns the documents reached by the last hop.
+	Related(doc models.Model, path ...string) ([]models.Model, error)
+
+	// RelatedContext follows relations like Related, and stops with the error of ctx once ctx is done.
+	RelatedContext(ctx context.Context, doc models.Model, path ...string) ([]models.Model, error)
+
+	// Walk follows every relation from doc up to depth hops, and returns the documents reached.
+	Walk(doc models.Model, depth int) ([]models.Model, error)
+
+	// WalkContext follows relations like Walk, and stops with the error of ctx once ctx is done.
+	WalkContext(ctx context.Context, doc models.Model, depth int) ([]models.Model, error)
+
+	// Upsert adds doc, replacing the document of the same type with the same _id,
+	// and updates the computed fields of the documents related to it.
+	// The store keeps doc, so to change a document later, upsert a changed copy of it.
+	Upsert(doc models.Model) error
+
+	// Delete removes the document of the given type with the given _id,
+	// and updates the computed fields of the documents that were related to it.
+	Delete(documentType, id string) error
+}
+
+// Versioned is implemented by a Store that counts the changes to its documents,
+// so that what was read from it can be recognised as out of date.
+type Versioned interface {
+	// Version increases whenever the documents change.
+	Version() uint64
+}
+
+// Find returns the document of the given type with the given _id, without the documents related to it.
+func Find(store Store, documentType, id string) (models.Model, error) {
+	return FindContext(context.Background(), store, documentType, id)
+}
+
+// FindContext finds a document like Find, and stops with the error of ctx once ctx is done.
+func FindContext(ctx context.Context, store Store, documentType, id string) (models.Model, error) {
+	found, err := store.SearchContext(ctx, documentType, "_id", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) > 0 {
+		return found[0].Document, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrDocumentNotFound, documentType, id)
 }
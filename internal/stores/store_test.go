@@ -1,14 +1,30 @@
 package stores_test
 
 import (
+	"bytes"
 	"cmp"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/satrap-illustrations/zs/internal/models"
 	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/doctype"
 	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/satrap-illustrations/zs/internal/tokeniser"
 	"gotest.tools/v3/assert"
 )
 
@@ -36,6 +52,13 @@ func TestStores(t *testing.T) {
 			query:    "Limozen",
 			expected: organization118Results,
 		},
+		{
+			name:     "organization_created_at_2016-02-11",
+			docType:  "Organizations",
+			field:    "created_at",
+			query:    "2016-02-11",
+			expected: organization118Results,
+		},
 		{
 			name:     "user_organization_id_118",
 			docType:  "Users",
@@ -88,8 +111,9 @@ func TestStores(t *testing.T) {
 						t.Skip("Deprecated. This tests allows checking compatibility with the new implementation.")
 					}
 
-					foundModels, err := ts.store.Search(tc.docType, tc.field, tc.query)
+					hits, err := ts.store.Search(tc.docType, tc.field, tc.query)
 					assert.NilError(t, err)
+					foundModels := flatten(hits)
 					slices.SortFunc(foundModels, sortFunc)
 					slices.SortFunc(tc.expected, sortFunc)
 					assert.DeepEqual(t, tc.expected, foundModels)
@@ -99,6 +123,54 @@ func TestStores(t *testing.T) {
 	}
 }
 
+func TestLocation(t *testing.T) {
+	t.Parallel()
+
+	// organization 118 was created at 2016-02-11T04:24:09 -11:00, which is the next day 10 hours ahead of UTC
+	aest := time.FixedZone("AEST", 10*60*60)
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore("../../data", implementations.WithLocation(aest))
+	assert.NilError(t, err)
+	invStore, err := implementations.NewInvertedStore("../../data", implementations.WithLocation(aest))
+	assert.NilError(t, err)
+
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			ids := func(query string) []string {
+				hits, err := ts.store.Search("Organizations", "created_at", query)
+				assert.NilError(t, err)
+				out := []string{}
+				for _, doc := range stores.Documents(hits) {
+					out = append(out, doc.StringID())
+				}
+				return out
+			}
+			assert.Assert(t, slices.Contains(ids("2016-02-12"), "118"))
+			assert.Assert(t, !slices.Contains(ids("2016-02-11"), "118"))
+			assert.DeepEqual(t, []string{"118"}, ids("2016-02-12T01:24:09 +10:00"))
+
+			// as are the documents upserted
+			found, err := stores.Find(ts.store, "Organizations", "118")
+			assert.NilError(t, err)
+			organization := *found.(*models.Organization)
+			organization.CreatedAt = models.MustParseTimestamp("2015-12-31T20:00:00 -10:00")
+			assert.NilError(t, ts.store.Upsert(&organization))
+			assert.DeepEqual(t, []string{"118"}, ids("2016-01-01"))
+			// without changing the document upserted
+			assert.Equal(t, "2015-12-31T20:00:00 -10:00", organization.CreatedAt.String())
+		})
+	}
+}
+
 func TestEmptyFieldsAreSerchable(t *testing.T) {
 	t.Parallel()
 
@@ -107,7 +179,7 @@ func TestEmptyFieldsAreSerchable(t *testing.T) {
 	store, err := implementations.NewInvertedStore(dataDir)
 	assert.NilError(t, err)
 
-	foundModels, err := store.Search("Tickets", "description", "")
+	hits, err := store.Search("Tickets", "description", "")
 	assert.NilError(t, err)
 
 	expected := []models.Model{
@@ -115,7 +187,7 @@ func TestEmptyFieldsAreSerchable(t *testing.T) {
 			ID:             uuid.Must(uuid.Parse("436bf9b0-1147-4c0a-8439-6f79833bff5b")),
 			URL:            "http://initech.zendesk.com/api/v2/tickets/436bf9b0-1147-4c0a-8439-6f79833bff5b.json",
 			ExternalID:     uuid.Must(uuid.Parse("9210cdc9-4bee-485f-a078-35396cd74063")),
-			CreatedAt:      "2016-04-28T11:19:34 -10:00",
+			CreatedAt:      models.MustParseTimestamp("2016-04-28T11:19:34 -10:00"),
 			Type:           "incident",
 			Subject:        "A Catastrophe in Korea (North)",
 			Priority:       "high",
@@ -124,14 +196,15 @@ func TestEmptyFieldsAreSerchable(t *testing.T) {
 			AssigneeID:     24,
 			OrganizationID: 116,
 			Tags:           []string{"Ohio", "Pennsylvania", "American Samoa", "Northern Mariana Islands"},
-			DueAt:          "2016-07-31T02:37:50 -10:00",
+			DueAt:          models.MustParseTimestamp("2016-07-31T02:37:50 -10:00"),
 			Via:            "web",
+			Overdue:        true,
 		},
 		&models.Ticket{
 			ID:             uuid.Must(uuid.Parse("4cce7415-ef12-42b6-b7b5-fb00e24f9cc1")),
 			URL:            "http://initech.zendesk.com/api/v2/tickets/4cce7415-ef12-42b6-b7b5-fb00e24f9cc1.json",
 			ExternalID:     uuid.Must(uuid.Parse("ef665694-aa3f-4960-b264-0e77c50486cf")),
-			CreatedAt:      "2016-02-25T09:12:47 -11:00",
+			CreatedAt:      models.MustParseTimestamp("2016-02-25T09:12:47 -11:00"),
 			Type:           "question",
 			Subject:        "A Nuisance in Ghana",
 			Priority:       "high",
@@ -140,14 +213,14 @@ func TestEmptyFieldsAreSerchable(t *testing.T) {
 			AssigneeID:     48,
 			OrganizationID: 104,
 			Tags:           []string{"Delaware", "New Hampshire", "Utah", "Hawaii"},
-			DueAt:          "2016-08-05T10:31:03 -10:00",
+			DueAt:          models.MustParseTimestamp("2016-08-05T10:31:03 -10:00"),
 			Via:            "web",
 		},
 		&models.Ticket{
 			ID:             uuid.Must(uuid.Parse("87db32c5-76a3-4069-954c-7d59c6c21de0")),
 			URL:            "http://initech.zendesk.com/api/v2/tickets/87db32c5-76a3-4069-954c-7d59c6c21de0.json",
 			ExternalID:     uuid.Must(uuid.Parse("1c61056c-a5ad-478a-9fd6-38889c3cd728")),
-			CreatedAt:      "2016-07-06T11:16:50 -10:00",
+			CreatedAt:      models.MustParseTimestamp("2016-07-06T11:16:50 -10:00"),
 			Type:           "problem",
 			Subject:        "A Problem in Morocco",
 			Priority:       "urgent",
@@ -157,17 +230,1500 @@ func TestEmptyFieldsAreSerchable(t *testing.T) {
 			OrganizationID: 118,
 			Tags:           []string{"Texas", "Nevada", "Oregon", "Arizona"},
 			HasIncidents:   true,
-			DueAt:          "2016-08-19T07:40:17 -10:00",
+			DueAt:          models.MustParseTimestamp("2016-08-19T07:40:17 -10:00"),
 			Via:            "voice",
 		},
 	}
 
+	// only the matched documents are relevant here, not the documents related to them
+	foundModels := stores.Documents(hits)
+
 	slices.SortFunc(foundModels, sortFunc)
 	slices.SortFunc(expected, sortFunc)
 
 	assert.DeepEqual(t, expected, foundModels)
 }
 
+// flatten returns each document that matched followed by the documents related to it, see stores.Hit.Models.
+func flatten(hits []stores.Hit) []models.Model {
+	out := []models.Model{}
+	for _, hit := range hits {
+		out = append(out, hit.Models()...)
+	}
+	return out
+}
+
+// flattened flattens the hits returned with err, or returns nil with the error.
+func flattened(hits []stores.Hit, err error) ([]models.Model, error) {
+	if err != nil {
+		return nil, err
+	}
+	return flatten(hits), nil
+}
+
 func sortFunc(a, b models.Model) int {
-	return cmp.Compare(a.DocumentType()+a.StringID(), b.DocumentType()+b.StringID())
+	return cmp.Compare(sortKey(a), sortKey(b))
+}
+
+func sortKey(m models.Model) string {
+	key := m.DocumentType() + m.StringID()
+	if r, ok := m.(*models.RelatedModel); ok {
+		key += r.Relation
+	}
+	return key
+}
+
+func TestInvalidEnumValues(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../test/fixtures/invalid_enums"
+
+	t.Run("strict", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := implementations.NewInvertedStore(dataDir, implementations.WithStrictValidation())
+		assert.ErrorIs(t, err, models.ErrInvalidValue)
+		assert.ErrorContains(t, err, `status "opne"`)
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		t.Parallel()
+
+		warnings := []error{}
+		store, err := implementations.NewInvertedStore(
+			dataDir,
+			implementations.WithWarnings(func(err error) { warnings = append(warnings, err) }),
+		)
+		assert.NilError(t, err)
+		assert.Equal(t, 2, len(warnings))
+		for _, warning := range warnings {
+			assert.ErrorIs(t, warning, models.ErrInvalidValue)
+		}
+
+		// the invalid values are still searchable
+		found, err := store.Search("Tickets", "status", "opne")
+		assert.NilError(t, err)
+		assert.Assert(t, len(found) > 0)
+	})
+}
+
+func TestListValues(t *testing.T) {
+	t.Parallel()
+
+	store, err := implementations.NewInvertedStore("../../data")
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, []string{"end-user", "agent", "admin"}, store.ListValues("Users", "role"))
+	assert.DeepEqual(t, []string(nil), store.ListValues("Users", "name"))
+	assert.DeepEqual(t, []string(nil), store.ListValues("Fake", "role"))
+}
+
+func TestExtraFieldsAreSearchable(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../test/fixtures/custom_fields"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			fields := ts.store.ListFields()
+			assert.Assert(t, slices.Contains(fields["Tickets"], "custom_fields.region"))
+			assert.Assert(t, slices.Contains(fields["Tickets"], "fields.value"))
+			assert.Assert(t, slices.Contains(fields["Users"], "user_fields.seats"))
+			assert.Assert(t, slices.Contains(fields["Organizations"], "organization_fields.tier"))
+
+			for _, tc := range []struct {
+				docType, field, query string
+				expectedIDs           []string
+			}{
+				{
+					docType:     "Tickets",
+					field:       "custom_fields.region",
+					query:       "EU",
+					expectedIDs: []string{"436bf9b0-1147-4c0a-8439-6f79833bff5b"},
+				},
+				{
+					docType:     "Tickets",
+					field:       "custom_fields.score",
+					query:       "3",
+					expectedIDs: []string{"1a227508-9f39-427c-8f57-1b72f3fab87c"},
+				},
+				{
+					docType:     "Tickets",
+					field:       "custom_fields.escalated",
+					query:       "true",
+					expectedIDs: []string{"436bf9b0-1147-4c0a-8439-6f79833bff5b"},
+				},
+				{
+					docType:     "Tickets",
+					field:       "fields.value",
+					query:       "gold",
+					expectedIDs: []string{"436bf9b0-1147-4c0a-8439-6f79833bff5b"},
+				},
+				{
+					docType:     "Users",
+					field:       "user_fields.seats",
+					query:       "10",
+					expectedIDs: []string{"1"},
+				},
+			} {
+				found, err := ts.store.Search(tc.docType, tc.field, tc.query)
+				assert.NilError(t, err)
+
+				ids := []string{}
+				for _, hit := range found {
+					ids = append(ids, hit.Document.StringID())
+				}
+				assert.DeepEqual(t, tc.expectedIDs, ids)
+			}
+
+			_, err := ts.store.Search("Tickets", "custom_fields.fake", "EU")
+			assert.Assert(t, err != nil)
+		})
+	}
+}
+
+func TestComputedFields(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name                  string
+		dataDir               string
+		now                   string
+		docType, field, query string
+		expectedIDs           []string
+	}{
+		{
+			name:        "overdue",
+			dataDir:     "../../test/fixtures/custom_fields",
+			now:         "2016-08-10T00:00:00 +00:00",
+			docType:     "Tickets",
+			field:       "overdue",
+			query:       "true",
+			expectedIDs: []string{"436bf9b0-1147-4c0a-8439-6f79833bff5b"},
+		},
+		{
+			name:        "not_yet_due",
+			dataDir:     "../../test/fixtures/custom_fields",
+			now:         "2016-07-01T00:00:00 +00:00",
+			docType:     "Tickets",
+			field:       "overdue",
+			query:       "true",
+			expectedIDs: []string{},
+		},
+		{
+			name:        "ticket_count",
+			dataDir:     "../../data",
+			now:         "2017-01-01T00:00:00 +00:00",
+			docType:     "Organizations",
+			field:       "ticket_count",
+			query:       "11",
+			expectedIDs: []string{"105", "107", "109", "118"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := implementations.NewInvertedStore(
+				tc.dataDir,
+				implementations.WithNow(models.MustParseTimestamp(tc.now).Time),
+			)
+			assert.NilError(t, err)
+
+			found, err := store.Search(tc.docType, tc.field, tc.query)
+			assert.NilError(t, err)
+
+			ids := []string{}
+			for _, hit := range found {
+				ids = append(ids, hit.Document.StringID())
+			}
+			assert.DeepEqual(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestGroupsCommentsAndAudits(t *testing.T) {
+	t.Parallel()
+
+	store, err := implementations.NewInvertedStore("../../test/fixtures/groups_comments_audits")
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t,
+		[]string{"Organizations", "Tickets", "Users", "Groups", "Comments", "Audits"},
+		store.ListDocumentTypes(),
+	)
+
+	for _, tc := range []struct {
+		name                  string
+		docType, field, query string
+		expectedIDs           []string
+	}{
+		{
+			name:        "group_name",
+			docType:     "Groups",
+			field:       "name",
+			query:       "Escalations",
+			expectedIDs: []string{"5"},
+		},
+		{
+			name:        "comments_by_author",
+			docType:     "Comments",
+			field:       "author_id",
+			query:       "73",
+			expectedIDs: []string{"11", "13", "16", "18"},
+		},
+		{
+			name:        "audits_of_ticket",
+			docType:     "Audits",
+			field:       "ticket_id",
+			query:       "436bf9b0-1147-4c0a-8439-6f79833bff5b",
+			expectedIDs: []string{"1", "2"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			found, err := store.Search(tc.docType, tc.field, tc.query)
+			assert.NilError(t, err)
+
+			ids := []string{}
+			for _, hit := range found {
+				ids = append(ids, hit.Document.StringID())
+			}
+			assert.DeepEqual(t, tc.expectedIDs, ids)
+		})
+	}
+
+	t.Run("relations", func(t *testing.T) {
+		t.Parallel()
+
+		ticket, err := stores.Find(store, "Tickets", "436bf9b0-1147-4c0a-8439-6f79833bff5b")
+		assert.NilError(t, err)
+		audits, err := store.Related(ticket, "audits")
+		assert.NilError(t, err)
+		assert.Equal(t, 2, len(audits))
+
+		group, err := store.Related(ticket, "group")
+		assert.NilError(t, err)
+		assert.Equal(t, 1, len(group))
+		assert.Equal(t, "4", group[0].StringID())
+
+		user, err := stores.Find(store, "Users", "73")
+		assert.NilError(t, err)
+		comments, err := store.Related(user, "comments")
+		assert.NilError(t, err)
+		assert.Equal(t, 4, len(comments))
+	})
+}
+
+func TestEveryDocumentType(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../test/fixtures/groups_comments_audits"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	ids := map[string]string{
+		"Organizations": "101",
+		"Tickets":       "436bf9b0-1147-4c0a-8439-6f79833bff5b",
+		"Users":         "1",
+		"Groups":        "1",
+		"Comments":      "1",
+		"Audits":        "1",
+	}
+
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			fields := ts.store.ListFields()
+			for _, docType := range ts.store.ListDocumentTypes() {
+				assert.Assert(t, slices.Contains(fields[docType], "_id"), docType)
+
+				doc, err := stores.Find(ts.store, docType, ids[docType])
+				assert.NilError(t, err, docType)
+				assert.Equal(t, ids[docType], doc.StringID())
+
+				_, err = ts.store.Search(docType, "fake", "1")
+				assert.ErrorIs(t, err, doctype.ErrInvalidField)
+			}
+		})
+	}
+}
+
+// writeDataDir writes the documents, as json objects, to a new data directory.
+func writeDataDir(t *testing.T, docs map[string][]map[string]any) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, objects := range docs {
+		buf, err := json.Marshal(objects)
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), buf, 0o600))
+	}
+	return dir
+}
+
+func readDataDir(t *testing.T, dir string) map[string][]map[string]any {
+	t.Helper()
+
+	docs := map[string][]map[string]any{}
+	for _, name := range []string{
+		"organizations.json", "tickets.json", "users.json", "groups.json", "comments.json", "audits.json",
+	} {
+		buf, err := os.ReadFile(filepath.Join(dir, name))
+		assert.NilError(t, err)
+		objects := []map[string]any{}
+		assert.NilError(t, json.Unmarshal(buf, &objects))
+		docs[name] = objects
+	}
+	return docs
+}
+
+func ticketFrom(t *testing.T, object map[string]any) *models.Ticket {
+	t.Helper()
+
+	buf, err := json.Marshal(object)
+	assert.NilError(t, err)
+	ticket := &models.Ticket{}
+	assert.NilError(t, json.Unmarshal(buf, ticket))
+	return ticket
+}
+
+func TestUpsertAndDeleteMatchFreshStore(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../test/fixtures/groups_comments_audits"
+
+	now := implementations.WithNow(time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC))
+	docs := readDataDir(t, dataDir)
+	tickets := docs["tickets.json"]
+
+	// a new ticket, submitted by user 1 in organization 101
+	added := maps.Clone(tickets[0])
+	added["_id"] = "11111111-2222-4333-8444-555555555555"
+	added["subject"] = "A Brand New Ticket"
+	added["submitter_id"] = 1
+	added["organization_id"] = 101
+	added["status"] = "open"
+	added["custom_fields"] = map[string]any{"region": "EU"}
+
+	// an existing ticket, moved to another submitter, organization and group, and solved
+	updated := maps.Clone(tickets[1])
+	updated["submitter_id"] = 1
+	updated["organization_id"] = 101
+	updated["group_id"] = 6
+	updated["status"] = "solved"
+	updated["tags"] = []any{"Ohio", "Texas"}
+
+	deleted := tickets[2]
+
+	expected := maps.Clone(docs)
+	expected["tickets.json"] = append([]map[string]any{tickets[0], updated}, append(slices.Clone(tickets[3:]), added)...)
+	expected["users.json"] = slices.DeleteFunc(slices.Clone(docs["users.json"]), func(user map[string]any) bool {
+		return user["_id"] == float64(2)
+	})
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir, now)
+	assert.NilError(t, err)
+	invStore, err := implementations.NewInvertedStore(dataDir, now)
+	assert.NilError(t, err)
+
+	//nolint:staticcheck
+	freshHashStore, err := implementations.NewHashStore(writeDataDir(t, expected), now)
+	assert.NilError(t, err)
+	freshInvStore, err := implementations.NewInvertedStore(writeDataDir(t, expected), now)
+	assert.NilError(t, err)
+
+	for _, ts := range []struct {
+		name         string
+		store, fresh stores.Store
+	}{
+		{name: "HashStore", store: hashStore, fresh: freshHashStore},
+		{name: "InvertedStore", store: invStore, fresh: freshInvStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.NilError(t, ts.store.Upsert(ticketFrom(t, added)))
+			assert.NilError(t, ts.store.Upsert(ticketFrom(t, updated)))
+			assert.NilError(t, ts.store.Delete("Tickets", deleted["_id"].(string)))
+			assert.NilError(t, ts.store.Delete("Users", "2"))
+
+			err := ts.store.Delete("Users", "2")
+			assert.ErrorIs(t, err, stores.ErrDocumentNotFound)
+
+			assert.DeepEqual(t, ts.fresh.ListFields(), ts.store.ListFields())
+
+			// search returns the found documents as strings, which are much quicker to compare than the models.
+			search := func(store stores.Store, docType, field, query string) []string {
+				found, err := store.Search(docType, field, query)
+				assert.NilError(t, err)
+				out := make([]string, 0, len(found))
+				for _, m := range flatten(found) {
+					relation := ""
+					if r, ok := m.(*models.RelatedModel); ok {
+						relation, m = r.Relation, r.Model
+					}
+					out = append(out, fmt.Sprintf("%s %+v", relation, m))
+				}
+				slices.Sort(out)
+				return out
+			}
+
+			// every document, with its computed fields and related documents
+			for name, objects := range expected {
+				docType := strings.ToUpper(name[:1]) + strings.TrimSuffix(name[1:], ".json")
+				for _, object := range objects {
+					id := fmt.Sprint(object["_id"])
+					assert.DeepEqual(t, search(ts.fresh, docType, "_id", id), search(ts.store, docType, "_id", id))
+				}
+			}
+			assert.DeepEqual(t, []string{}, search(ts.store, "Tickets", "_id", deleted["_id"].(string)))
+
+			// the postings of the tokens of the tickets before and after the changes
+			for _, object := range []map[string]any{tickets[1], tickets[2], added, updated} {
+				for _, token := range tokeniser.Tokenise(ticketFrom(t, object)) {
+					assert.DeepEqual(t,
+						search(ts.fresh, "Tickets", token.Field, token.Text),
+						search(ts.store, "Tickets", token.Field, token.Text),
+					)
+				}
+			}
+		})
+	}
+}
+
+// otherUser is a document of the Users type that is not a *models.User, which the stores cannot keep.
+type otherUser struct {
+	models.User
+}
+
+func (u *otherUser) Clone() models.Model {
+	clone := *u
+	return &clone
+}
+
+func TestUpsertWrappedAndInvalidDocuments(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../data"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			found, err := stores.Find(ts.store, "Users", "1")
+			assert.NilError(t, err)
+			user, ok := found.(*models.User)
+			assert.Assert(t, ok)
+
+			// a document as Related or a multi store returns it is upserted without its relation and source
+			renamed := *user
+			renamed.Name = "Francisca Renamed"
+			wrapped := &models.RelatedModel{Model: &models.SourcedModel{Model: &renamed, Source: "eu"}, Relation: "submitter"}
+			assert.NilError(t, ts.store.Upsert(wrapped))
+			found, err = stores.Find(ts.store, "Users", "1")
+			assert.NilError(t, err)
+			stored, ok := found.(*models.User)
+			assert.Assert(t, ok)
+			assert.Equal(t, "Francisca Renamed", stored.Name)
+			assert.Equal(t, user.SubmittedTicketCount, stored.SubmittedTicketCount)
+
+			// and one the store cannot keep does not lose the document it would have replaced
+			other := &otherUser{User: *user}
+			other.Name = "Not Stored"
+			assert.ErrorIs(t, ts.store.Upsert(other), implementations.ErrInvalidDocType)
+			found, err = stores.Find(ts.store, "Users", "1")
+			assert.NilError(t, err)
+			assert.Equal(t, stored, found)
+		})
+	}
+}
+
+// TestConcurrentSearchAndUpsert is meant to be run with the race detector, i.e. go test -race.
+func TestConcurrentSearchAndUpsert(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../data"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			original, err := stores.Find(ts.store, "Tickets", "436bf9b0-1147-4c0a-8439-6f79833bff5b")
+			assert.NilError(t, err)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 100)
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 20; j++ {
+						found, err := ts.store.Search("Users", "_id", "38")
+						if err != nil {
+							errs <- err
+							return
+						}
+						// read the documents, as a caller would
+						for _, m := range flatten(found) {
+							if _, err := models.StringOf(m); err != nil {
+								errs <- err
+								return
+							}
+						}
+						if _, err := ts.store.Walk(found[0].Document, 2); err != nil {
+							errs <- err
+							return
+						}
+						ts.store.ListFields()
+					}
+				}()
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					ticket := original.Clone().(*models.Ticket)
+					ticket.SubmitterID = 38 + j%2
+					if err := ts.store.Upsert(ticket); err != nil {
+						errs <- err
+						return
+					}
+					if j%5 == 0 {
+						if err := ts.store.Delete("Tickets", ticket.StringID()); err != nil {
+							errs <- err
+							return
+						}
+					}
+				}
+			}()
+
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadProgress(t *testing.T) {
+	t.Parallel()
+
+	last := map[string]implementations.Progress{}
+	_, err := implementations.NewInvertedStore(
+		"../../data",
+		implementations.WithProgress(func(p implementations.Progress) {
+			previous := last[p.File]
+			assert.Assert(t, !previous.Done, p.File)
+			assert.Assert(t, p.Documents >= previous.Documents, p.File)
+			assert.Assert(t, p.Read >= previous.Read && p.Read <= p.Size, p.File)
+			last[p.File] = p
+		}),
+	)
+	assert.NilError(t, err)
+
+	for file, documents := range map[string]int{
+		"organizations.json": 25,
+		"tickets.json":       200,
+		"users.json":         75,
+	} {
+		p := last[file]
+		assert.Assert(t, p.Done, file)
+		assert.Equal(t, documents, p.Documents, file)
+		assert.Assert(t, p.Size > 0 && p.Size-p.Read <= 1, file)
+	}
+	// the optional files are not in the sample data
+	for _, file := range []string{"groups.json", "comments.json", "audits.json"} {
+		_, reported := last[file]
+		assert.Assert(t, !reported, file)
+	}
+}
+
+func TestMalformedDataFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		// file is the name of the data file with contents, tickets.json if empty, which replaces the JSON file
+		// with the same base name.
+		file, contents string
+		expectedError  error
+		expectedMsg    string
+	}{
+		{
+			name:          "object",
+			contents:      `{"_id": "436bf9b0-1147-4c0a-8439-6f79833bff5b"}`,
+			expectedError: implementations.ErrNotAnArray,
+			expectedMsg:   "tickets.json",
+		},
+		{
+			name:        "invalid_document",
+			contents:    `[{"_id": "436bf9b0-1147-4c0a-8439-6f79833bff5b"}, {"_id": 12}]`,
+			expectedMsg: "document 1",
+		},
+		{
+			name:        "truncated",
+			contents:    `[{"_id": "436bf9b0-1147-4c0a-8439-6f79833bff5b"}, {"_id"`,
+			expectedMsg: "tickets.json",
+		},
+		{
+			name:        "invalid_line",
+			file:        "tickets.ndjson",
+			contents:    "{\"_id\": \"436bf9b0-1147-4c0a-8439-6f79833bff5b\"}\n\n{\"_id\": 12}\n",
+			expectedMsg: "tickets.ndjson: line 3",
+		},
+		{
+			name:        "truncated_line",
+			file:        "tickets.jsonl",
+			contents:    "{\"_id\": \"436bf9b0-1147-4c0a-8439-6f79833bff5b\"}\n{\"_id\"",
+			expectedMsg: "tickets.jsonl: line 2",
+		},
+		{
+			name:        "csv_invalid_int",
+			file:        "users.csv",
+			contents:    "_id,name,organization_id\n1,Francisca Rasmussen,x\n",
+			expectedMsg: `users.csv: line 2: column "organization_id"`,
+		},
+		{
+			name:        "csv_wrong_number_of_cells",
+			file:        "users.csv",
+			contents:    "_id,name\n1,Francisca Rasmussen\n\"Francisca\nRasmussen\"\n",
+			expectedMsg: "line 3",
+		},
+		{
+			name:          "csv_computed_field",
+			file:          "users.csv",
+			contents:      "_id,submitted_ticket_count\n1,3\n",
+			expectedError: implementations.ErrInvalidMapping,
+			expectedMsg:   "submitted_ticket_count",
+		},
+		{
+			name:          "csv_field_inside_another",
+			file:          "organizations.csv",
+			contents:      "_id,custom_fields,custom_fields.region\n101,x,EU\n",
+			expectedError: implementations.ErrInvalidMapping,
+			expectedMsg:   `column "custom_fields.region" is mapped to custom_fields.region, inside the field custom_fields`,
+		},
+		{
+			name:          "csv_tickets",
+			file:          "tickets.csv",
+			contents:      "_id,subject\n436bf9b0-1147-4c0a-8439-6f79833bff5b,A Problem\n",
+			expectedError: implementations.ErrUnsupportedDataFile,
+			expectedMsg:   "tickets.csv",
+		},
+		{
+			name:        "truncated_gzip",
+			file:        "tickets.json.gz",
+			contents:    "\x1f\x8b\x08\x00",
+			expectedMsg: "tickets.json.gz",
+		},
+		{
+			name:          "gzipped_and_not",
+			file:          "tickets.json.gz",
+			contents:      "",
+			expectedError: implementations.ErrAmbiguousDataFile,
+			expectedMsg:   "tickets.json, tickets.json.gz",
+		},
+		{
+			name:          "array_and_lines",
+			file:          "tickets.jsonl",
+			contents:      "",
+			expectedError: implementations.ErrAmbiguousDataFile,
+			expectedMsg:   "tickets.json, tickets.jsonl",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			file := tc.file
+			if file == "" {
+				file = "tickets.json"
+			}
+			files := map[string]string{
+				"organizations.json": "[]",
+				"tickets.json":       "[]",
+				"users.json":         "[]",
+			}
+			if !errors.Is(tc.expectedError, implementations.ErrAmbiguousDataFile) {
+				delete(files, strings.Split(file, ".")[0]+".json")
+			}
+			files[file] = tc.contents
+			for name, contents := range files {
+				assert.NilError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600))
+			}
+
+			_, err := implementations.NewInvertedStore(dir)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			}
+			assert.ErrorContains(t, err, tc.expectedMsg)
+		})
+	}
+}
+
+// writeJSONLines writes the documents of the data file name in from to a JSON lines file in to, with the extension ext.
+func writeJSONLines(t *testing.T, from, to, name, ext string) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(from, name))
+	assert.NilError(t, err)
+	var docs []json.RawMessage
+	assert.NilError(t, json.Unmarshal(data, &docs))
+
+	var lines strings.Builder
+	for _, doc := range docs {
+		var compact bytes.Buffer
+		assert.NilError(t, json.Compact(&compact, doc))
+		lines.Write(compact.Bytes())
+		lines.WriteString("\n")
+	}
+	assert.NilError(t, os.WriteFile(filepath.Join(to, strings.TrimSuffix(name, ".json")+ext), []byte(lines.String()), 0o600))
+}
+
+func TestJSONLines(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../data"
+	dir := t.TempDir()
+	writeJSONLines(t, dataDir, dir, "organizations.json", ".ndjson")
+	writeJSONLines(t, dataDir, dir, "tickets.json", ".jsonl")
+	writeJSONLines(t, dataDir, dir, "users.json", ".ndjson")
+
+	files := map[string]implementations.Progress{}
+	lines, err := implementations.NewInvertedStore(dir, implementations.WithProgress(func(p implementations.Progress) {
+		files[p.File] = p
+	}))
+	assert.NilError(t, err)
+	assert.Equal(t, 200, files["tickets.jsonl"].Documents)
+	assert.Assert(t, files["tickets.jsonl"].Done)
+	assert.Equal(t, files["tickets.jsonl"].Size, files["tickets.jsonl"].Read)
+
+	arrays, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+	for _, docType := range []string{"Organizations", "Tickets", "Users"} {
+		expected, err := arrays.Search(docType, "created_at", "2016")
+		assert.NilError(t, err)
+		found, err := lines.Search(docType, "created_at", "2016")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, stores.Documents(expected), stores.Documents(found))
+	}
+}
+
+// writeGzip writes data gzipped to the file at path.
+func writeGzip(t *testing.T, path string, data []byte) {
+	t.Helper()
+
+	var compressed bytes.Buffer
+	z := gzip.NewWriter(&compressed)
+	_, err := z.Write(data)
+	assert.NilError(t, err)
+	assert.NilError(t, z.Close())
+	assert.NilError(t, os.WriteFile(path, compressed.Bytes(), 0o600))
+}
+
+func TestGzip(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../data"
+	dir := t.TempDir()
+	for _, file := range []struct{ from, to string }{
+		{from: "organizations.json", to: "organizations.json.gz"},
+		// the magic bytes are enough, whatever the extension
+		{from: "users.json", to: "users.json"},
+	} {
+		data, err := os.ReadFile(filepath.Join(dataDir, file.from))
+		assert.NilError(t, err)
+		writeGzip(t, filepath.Join(dir, file.to), data)
+	}
+	writeJSONLines(t, dataDir, dir, "tickets.json", ".jsonl")
+	data, err := os.ReadFile(filepath.Join(dir, "tickets.jsonl"))
+	assert.NilError(t, err)
+	assert.NilError(t, os.Remove(filepath.Join(dir, "tickets.jsonl")))
+	writeGzip(t, filepath.Join(dir, "tickets.jsonl.gz"), data)
+
+	files := map[string]implementations.Progress{}
+	compressed, err := implementations.NewInvertedStore(dir, implementations.WithProgress(func(p implementations.Progress) {
+		assert.Assert(t, p.Read <= p.Size, p.File)
+		files[p.File] = p
+	}))
+	assert.NilError(t, err)
+	for file, documents := range map[string]int{
+		"organizations.json.gz": 25,
+		"tickets.jsonl.gz":      200,
+		"users.json":            75,
+	} {
+		p := files[file]
+		assert.Assert(t, p.Done, file)
+		assert.Equal(t, documents, p.Documents, file)
+		assert.Equal(t, p.Size, p.Read, file)
+	}
+
+	uncompressed, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+	for _, docType := range []string{"Organizations", "Tickets", "Users"} {
+		expected, err := uncompressed.Search(docType, "created_at", "2016")
+		assert.NilError(t, err)
+		found, err := compressed.Search(docType, "created_at", "2016")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, stores.Documents(expected), stores.Documents(found))
+	}
+}
+
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	store, err := implementations.NewInvertedStore("../../test/fixtures/csv")
+	assert.NilError(t, err)
+
+	// the columns are mapped to fields, and the cells are split into lists and parsed
+	user, err := stores.Find(store, "Users", "2")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &models.User{
+		ID:             2,
+		Name:           "Cross Barlow, Jr.",
+		Email:          "jonibarlow@flotonic.com",
+		OrganizationID: 102,
+		Role:           "end-user",
+		CreatedAt:      models.MustParseTimestamp("2016-06-23T10:31:39 -10:00"),
+		Tags:           []string{"Foxworth", "Woodlands"},
+		Extras:         models.Extras{"custom_fields": map[string]any{"region": "EMEA"}},
+	}, user)
+
+	organization, err := stores.Find(store, "Organizations", "101")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, &models.Organization{
+		ID:          101,
+		URL:         "http://initech.zendesk.com/api/v2/organizations/101.json",
+		ExternalID:  uuid.MustParse("9270ed79-35eb-4a38-a46f-35725197ea8d"),
+		Name:        "Enthaze",
+		DomainNames: []string{"kage.com", "ecratic.com", "endipin.com", "zentix.com"},
+		CreatedAt:   models.MustParseTimestamp("2016-05-21T11:10:28 -10:00"),
+		Details:     "MegaCorp",
+		Tags:        []string{"Fulton", "West", "Rodriguez", "Farley"},
+		UserCount:   2,
+	}, organization)
+
+	// and they are searched like the documents of the json files
+	for _, tc := range []struct {
+		docType, field, query string
+		expected              []string
+	}{
+		{docType: "Users", field: "tags", query: "Hartsville/Hartley", expected: []string{"1"}},
+		{docType: "Users", field: "active", query: "false", expected: []string{"2", "3"}},
+		{docType: "Users", field: "custom_fields.region", query: "APAC", expected: []string{"1"}},
+		{docType: "Users", field: "email", query: "", expected: []string{"3"}},
+		{docType: "Organizations", field: "tags", query: "", expected: []string{}},
+		{docType: "Organizations", field: "domain_names", query: "datagen.com", expected: []string{"102"}},
+	} {
+		hits, err := store.Search(tc.docType, tc.field, tc.query)
+		assert.NilError(t, err)
+		found := []string{}
+		for _, doc := range stores.Documents(hits) {
+			found = append(found, doc.StringID())
+		}
+		slices.Sort(found)
+		assert.DeepEqual(t, tc.expected, found)
+	}
+	_, err = store.Search("Users", "Notes", "")
+	assert.ErrorIs(t, err, doctype.ErrInvalidField)
+}
+
+// cancelledAfter is a context whose Err is nil for the first n calls and context.Canceled after,
+// to cancel a search part of the way through.
+type cancelledAfter struct {
+	context.Context
+	n atomic.Int32
+}
+
+func newCancelledAfter(n int32) *cancelledAfter {
+	c := &cancelledAfter{Context: context.Background()}
+	c.n.Store(n)
+	return c
+}
+
+func (c *cancelledAfter) Err() error {
+	if c.n.Add(-1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestSearchContext(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../test/fixtures/groups_comments_audits"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	organization := &models.Organization{ID: 101}
+
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		for _, tc := range []struct {
+			name          string
+			search        func(ctx context.Context) ([]models.Model, error)
+			ctx           context.Context
+			expectedError error
+		}{
+			{
+				name: "search_cancelled",
+				search: func(ctx context.Context) ([]models.Model, error) {
+					return flattened(ts.store.SearchContext(ctx, "Tickets", "status", "pending"))
+				},
+				ctx:           cancelled,
+				expectedError: context.Canceled,
+			},
+			{
+				name: "search_expired",
+				search: func(ctx context.Context) ([]models.Model, error) {
+					return flattened(ts.store.SearchContext(ctx, "Tickets", "status", "pending"))
+				},
+				ctx:           expired,
+				expectedError: context.DeadlineExceeded,
+			},
+			{
+				name: "search_cancelled_while_scanning",
+				search: func(ctx context.Context) ([]models.Model, error) {
+					// the hash store scans the 24 audits, the inverted store has the 16 via the web in one posting list.
+					return flattened(ts.store.SearchContext(ctx, "Audits", "via", "web"))
+				},
+				ctx:           newCancelledAfter(1),
+				expectedError: context.Canceled,
+			},
+			{
+				name: "search_cancelled_while_adding_related_documents",
+				search: func(ctx context.Context) ([]models.Model, error) {
+					return flattened(ts.store.SearchContext(ctx, "Organizations", "_id", "101"))
+				},
+				ctx:           newCancelledAfter(1),
+				expectedError: context.Canceled,
+			},
+			{
+				name: "related_cancelled",
+				search: func(ctx context.Context) ([]models.Model, error) {
+					return ts.store.RelatedContext(ctx, organization, "users", "submitted_tickets")
+				},
+				ctx:           cancelled,
+				expectedError: context.Canceled,
+			},
+			{
+				name: "walk_expired",
+				search: func(ctx context.Context) ([]models.Model, error) {
+					return ts.store.WalkContext(ctx, organization, 2)
+				},
+				ctx:           expired,
+				expectedError: context.DeadlineExceeded,
+			},
+			{
+				name: "search_not_cancelled",
+				search: func(ctx context.Context) ([]models.Model, error) {
+					return flattened(ts.store.SearchContext(ctx, "Audits", "via", "web"))
+				},
+				ctx: context.Background(),
+			},
+		} {
+			tc := tc
+			t.Run(ts.name+"_"+tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				found, err := tc.search(tc.ctx)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+					assert.Assert(t, found == nil)
+					return
+				}
+				assert.NilError(t, err)
+				assert.Assert(t, len(found) > 0)
+			})
+		}
+	}
+}
+
+// matchesOf returns the _ids of the documents on a page that match, without the documents related to them.
+func matchesOf(page stores.Page) []string {
+	ids := []string{}
+	for _, doc := range stores.Documents(page.Hits) {
+		ids = append(ids, doc.StringID())
+	}
+	return ids
+}
+
+func TestSearchPage(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../data"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			all, err := ts.store.SearchPage(ctx, "Users", "role", "admin", stores.PageRequest{})
+			assert.NilError(t, err)
+			expected := matchesOf(all)
+			assert.Equal(t, len(expected), all.Total)
+			assert.Equal(t, "", all.NextCursor)
+			assert.Assert(t, len(expected) > 2)
+			assert.Assert(t, slices.IsSortedFunc(expected, func(a, b string) int {
+				x, _ := strconv.Atoi(a)
+				y, _ := strconv.Atoi(b)
+				return cmp.Compare(x, y)
+			}))
+
+			t.Run("cursor", func(t *testing.T) {
+				t.Parallel()
+
+				ids := []string{}
+				page := stores.Page{}
+				for pages := 0; pages == 0 || page.NextCursor != ""; pages++ {
+					page, err = ts.store.SearchPage(
+						ctx, "Users", "role", "admin", stores.PageRequest{Limit: 2, Cursor: page.NextCursor},
+					)
+					assert.NilError(t, err)
+					assert.Equal(t, all.Total, page.Total)
+					assert.Assert(t, len(matchesOf(page)) <= 2)
+					ids = append(ids, matchesOf(page)...)
+				}
+				assert.DeepEqual(t, expected, ids)
+			})
+
+			t.Run("offset", func(t *testing.T) {
+				t.Parallel()
+
+				page, err := ts.store.SearchPage(ctx, "Users", "role", "admin", stores.PageRequest{Limit: 2, Offset: 1})
+				assert.NilError(t, err)
+				assert.DeepEqual(t, expected[1:3], matchesOf(page))
+
+				page, err = ts.store.SearchPage(ctx, "Users", "role", "admin", stores.PageRequest{Offset: 1000})
+				assert.NilError(t, err)
+				assert.Equal(t, 0, len(page.Hits))
+				assert.Equal(t, all.Total, page.Total)
+				assert.Equal(t, "", page.NextCursor)
+			})
+
+			t.Run("invalid", func(t *testing.T) {
+				t.Parallel()
+
+				first, err := ts.store.SearchPage(ctx, "Users", "role", "admin", stores.PageRequest{Limit: 1})
+				assert.NilError(t, err)
+
+				for _, page := range []stores.PageRequest{
+					{Limit: -1},
+					{Offset: -1},
+					{Cursor: "not a cursor"},
+					{Cursor: first.NextCursor + "x"},
+				} {
+					_, err := ts.store.SearchPage(ctx, "Users", "role", "admin", page)
+					assert.ErrorIs(t, err, stores.ErrInvalidPage)
+				}
+				_, err = ts.store.SearchPage(ctx, "Users", "role", "agent", stores.PageRequest{Cursor: first.NextCursor})
+				assert.ErrorIs(t, err, stores.ErrInvalidPage)
+			})
+		})
+	}
+
+	t.Run("cursor_after_deleted_document", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := implementations.NewInvertedStore(dataDir)
+		assert.NilError(t, err)
+
+		first, err := store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Limit: 3})
+		assert.NilError(t, err)
+		all, err := store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{})
+		assert.NilError(t, err)
+
+		// the last ticket on the first page is deleted, and the next page still starts after it.
+		firstIDs := matchesOf(first)
+		assert.NilError(t, store.Delete("Tickets", firstIDs[2]))
+		next, err := store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Limit: 3, Cursor: first.NextCursor})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, matchesOf(all)[3:6], matchesOf(next))
+		assert.Equal(t, all.Total-1, next.Total)
+	})
+}
+
+func TestParseSort(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		spec     string
+		expected stores.Sort
+		err      error
+	}{
+		{spec: "", expected: nil},
+		{spec: "created_at", expected: stores.Sort{{Field: "created_at"}}},
+		{
+			spec:     "sort=created_at desc, priority asc",
+			expected: stores.Sort{{Field: "created_at", Descending: true}, {Field: "priority"}},
+		},
+		{spec: "custom_fields.region DESC", expected: stores.Sort{{Field: "custom_fields.region", Descending: true}}},
+		{spec: "created_at down", err: stores.ErrInvalidSort},
+		{spec: "created_at desc,", err: stores.ErrInvalidSort},
+		{spec: "created_at desc priority", err: stores.ErrInvalidSort},
+	} {
+		tc := tc
+		t.Run(tc.spec, func(t *testing.T) {
+			t.Parallel()
+
+			sort, err := stores.ParseSort(tc.spec)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, sort)
+
+			reparsed, err := stores.ParseSort(sort.String())
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, reparsed)
+		})
+	}
+}
+
+func TestSearchPageSort(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../data"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	sort, err := stores.ParseSort("priority asc, created_at desc")
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			all, err := ts.store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Sort: sort})
+			assert.NilError(t, err)
+			tickets := []*models.Ticket{}
+			for _, doc := range stores.Documents(all.Hits) {
+				if ticket, ok := doc.(*models.Ticket); ok {
+					tickets = append(tickets, ticket)
+				}
+			}
+			assert.Equal(t, all.Total, len(tickets))
+			assert.Assert(t, slices.IsSortedFunc(tickets, func(a, b *models.Ticket) int {
+				if c := models.CompareValues(a.Priority, b.Priority); c != 0 {
+					return c
+				}
+				return -a.CreatedAt.Compare(b.CreatedAt.Time)
+			}))
+			assert.Equal(t, models.TicketPriorityUrgent, tickets[0].Priority)
+			assert.Equal(t, models.TicketPriorityLow, tickets[len(tickets)-1].Priority)
+
+			ids := []string{}
+			page := stores.Page{}
+			for pages := 0; pages == 0 || page.NextCursor != ""; pages++ {
+				page, err = ts.store.SearchPage(
+					ctx, "Tickets", "status", "pending", stores.PageRequest{Limit: 4, Cursor: page.NextCursor, Sort: sort},
+				)
+				assert.NilError(t, err)
+				ids = append(ids, matchesOf(page)...)
+			}
+			assert.DeepEqual(t, matchesOf(all), ids)
+
+			first, err := ts.store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Limit: 4, Sort: sort})
+			assert.NilError(t, err)
+			_, err = ts.store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Cursor: first.NextCursor})
+			assert.ErrorIs(t, err, stores.ErrInvalidPage)
+
+			_, err = ts.store.SearchPage(
+				ctx, "Tickets", "status", "pending", stores.PageRequest{Sort: stores.Sort{{Field: "colour"}}},
+			)
+			assert.ErrorIs(t, err, stores.ErrInvalidSort)
+		})
+	}
+}
+
+func TestParseFacet(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		spec     string
+		expected stores.Facet
+		err      error
+	}{
+		{spec: "priority", expected: stores.Facet{Field: "priority", Kind: stores.Terms}},
+		{spec: "priority:terms", expected: stores.Facet{Field: "priority", Kind: stores.Terms}},
+		{spec: "created_at:histogram", expected: stores.Facet{Field: "created_at", Kind: stores.DateHistogram, Interval: stores.Month}},
+		{spec: "created_at:year", expected: stores.Facet{Field: "created_at", Kind: stores.DateHistogram, Interval: stores.Year}},
+		{spec: " user_count : stats ", expected: stores.Facet{Field: "user_count", Kind: stores.Stats}},
+		{spec: "", err: stores.ErrInvalidFacet},
+		{spec: "created_at:week", err: stores.ErrInvalidFacet},
+	} {
+		tc := tc
+		t.Run(tc.spec, func(t *testing.T) {
+			t.Parallel()
+
+			facet, err := stores.ParseFacet(tc.spec)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, facet)
+
+			reparsed, err := stores.ParseFacet(facet.String())
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.expected, reparsed)
+		})
+	}
+}
+
+func TestSearchPageFacets(t *testing.T) {
+	t.Parallel()
+
+	const dataDir = "../../data"
+
+	//nolint:staticcheck
+	hashStore, err := implementations.NewHashStore(dataDir)
+	assert.NilError(t, err)
+
+	invStore, err := implementations.NewInvertedStore(dataDir)
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+	for _, ts := range []struct {
+		name  string
+		store stores.Store
+	}{
+		{name: "HashStore", store: hashStore},
+		{name: "InvertedStore", store: invStore},
+	} {
+		ts := ts
+		t.Run(ts.name, func(t *testing.T) {
+			t.Parallel()
+
+			all, err := ts.store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{})
+			assert.NilError(t, err)
+			priorities := map[string]int{}
+			organizations := map[string]int{}
+			months := map[string]int{}
+			for _, doc := range stores.Documents(all.Hits) {
+				if ticket, ok := doc.(*models.Ticket); ok {
+					priorities[ticket.Priority.String()]++
+					organizations[strconv.Itoa(ticket.OrganizationID)]++
+					months[ticket.CreatedAt.Format(models.MonthLayout)]++
+				}
+			}
+
+			page, err := ts.store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{
+				Limit: 1,
+				Facets: []stores.Facet{
+					{Field: "priority", Kind: stores.Terms},
+					{Field: "organization_id", Kind: stores.Terms},
+					{Field: "created_at", Kind: stores.DateHistogram, Interval: stores.Month},
+				},
+			})
+			assert.NilError(t, err)
+			assert.Equal(t, 3, len(page.Facets))
+
+			bucketCounts := func(buckets []stores.Bucket) map[string]int {
+				out := map[string]int{}
+				for _, bucket := range buckets {
+					out[bucket.Key] = bucket.Count
+				}
+				return out
+			}
+			assert.DeepEqual(t, priorities, bucketCounts(page.Facets[0].Buckets))
+			assert.Assert(t, slices.IsSortedFunc(page.Facets[0].Buckets, func(a, b stores.Bucket) int {
+				return cmp.Compare(b.Count, a.Count)
+			}))
+			assert.DeepEqual(t, organizations, bucketCounts(page.Facets[1].Buckets))
+			assert.DeepEqual(t, months, bucketCounts(page.Facets[2].Buckets))
+			assert.Assert(t, slices.IsSortedFunc(page.Facets[2].Buckets, func(a, b stores.Bucket) int {
+				return cmp.Compare(a.Key, b.Key)
+			}))
+
+			users, err := ts.store.SearchPage(ctx, "Users", "role", "admin", stores.PageRequest{
+				Facets: []stores.Facet{{Field: "submitted_ticket_count", Kind: stores.Stats}},
+			})
+			assert.NilError(t, err)
+			expected := stores.Summary{}
+			for _, doc := range stores.Documents(users.Hits) {
+				if user, ok := doc.(*models.User); ok {
+					count := float64(user.SubmittedTicketCount)
+					if expected.Count == 0 || count < expected.Min {
+						expected.Min = count
+					}
+					expected.Max = max(expected.Max, count)
+					expected.Sum += count
+					expected.Count++
+				}
+			}
+			expected.Mean = expected.Sum / float64(expected.Count)
+			assert.DeepEqual(t, expected, users.Facets[0].Summary)
+
+			for _, facet := range []stores.Facet{
+				{Field: "colour", Kind: stores.Terms},
+				{Field: "subject", Kind: stores.DateHistogram},
+				{Field: "subject", Kind: stores.Stats},
+				{Field: "subject", Kind: "median"},
+			} {
+				_, err := ts.store.SearchPage(ctx, "Tickets", "status", "pending", stores.PageRequest{Facets: []stores.Facet{facet}})
+				assert.ErrorIs(t, err, stores.ErrInvalidFacet)
+			}
+		})
+	}
+}
+
+func TestNewHit(t *testing.T) {
+	t.Parallel()
+
+	ticket := &models.Ticket{ID: uuid.Must(uuid.Parse("436bf9b0-1147-4c0a-8439-6f79833bff5b"))}
+	user := &models.User{ID: 38}
+	organization := &models.Organization{ID: 116}
+	hit := stores.NewHit(ticket, []string{"status"}, []models.Model{
+		&models.RelatedModel{Model: user, Relation: "submitter"},
+		&models.RelatedModel{Model: user, Relation: "assignee"},
+		&models.RelatedModel{Model: organization, Relation: "organization"},
+		&models.RelatedModel{Model: organization, Relation: "organization"},
+	})
+
+	assert.DeepEqual(t, stores.Hit{
+		Document:      ticket,
+		MatchedFields: []string{"status"},
+		Related: map[string][]models.Model{
+			"assignee":     {user},
+			"organization": {organization},
+			"submitter":    {user},
+		},
+	}, hit)
+	assert.DeepEqual(t, []models.Model{
+		ticket,
+		&models.RelatedModel{Model: user, Relation: "assignee"},
+		&models.RelatedModel{Model: organization, Relation: "organization"},
+		&models.RelatedModel{Model: user, Relation: "submitter"},
+	}, hit.Models())
+}
+
+func TestSearchHits(t *testing.T) {
+	t.Parallel()
+
+	store, err := implementations.NewInvertedStore("../../data")
+	assert.NilError(t, err)
+
+	hits, err := store.Search("Organizations", "_id", "118")
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(hits))
+	assert.Equal(t, "118", hits[0].Document.StringID())
+	assert.DeepEqual(t, []string{"_id"}, hits[0].MatchedFields)
+
+	for _, relation := range []string{"tickets", "users"} {
+		related := hits[0].Related[relation]
+		assert.Assert(t, len(related) > 0, relation)
+		for _, doc := range related {
+			_, wrapped := doc.(*models.RelatedModel)
+			assert.Assert(t, !wrapped, relation)
+		}
+	}
+	assert.Equal(t, 11, len(hits[0].Related["tickets"]))
 }
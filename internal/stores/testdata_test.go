@@ -14,243 +14,292 @@ var (
 			ExternalID:  uuid.Must(uuid.Parse("6970300e-f211-4c01-a538-70b4464a1d84")),
 			Name:        "Limozen",
 			DomainNames: []string{"otherway.com", "rodeomad.com", "suremax.com", "fishland.com"},
-			CreatedAt:   "2016-02-11T04:24:09 -11:00",
+			CreatedAt:   models.MustParseTimestamp("2016-02-11T04:24:09 -11:00"),
 			Details:     "MegaCorp",
 			Tags:        []string{"Leon", "Ferguson", "Olsen", "Walsh"},
+			UserCount:   2,
+			TicketCount: 11,
+		},
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("87db32c5-76a3-4069-954c-7d59c6c21de0")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/87db32c5-76a3-4069-954c-7d59c6c21de0.json",
+				ExternalID:     uuid.Must(uuid.Parse("1c61056c-a5ad-478a-9fd6-38889c3cd728")),
+				CreatedAt:      models.MustParseTimestamp("2016-07-06T11:16:50 -10:00"),
+				Type:           "problem",
+				Subject:        "A Problem in Morocco",
+				Description:    "Sit culpa non magna anim. Ea velit qui nostrud eiusmod laboris dolor adipisicing quis deserunt elit amet.",
+				Priority:       "urgent",
+				Status:         "solved",
+				SubmitterID:    14,
+				AssigneeID:     7,
+				OrganizationID: 118,
+				Tags:           []string{"Texas", "Nevada", "Oregon", "Arizona"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-19T07:40:17 -10:00"),
+				Via:            "voice",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("0ebe753c-9c78-458a-817f-3993780bedbf")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/0ebe753c-9c78-458a-817f-3993780bedbf.json",
-			ExternalID:     uuid.Must(uuid.Parse("537ad752-9056-42c9-86db-f0bdf06d3c10")),
-			CreatedAt:      "2016-05-19T12:19:56 -10:00",
-			Type:           "problem",
-			Subject:        "A Nuisance in Seychelles",
-			Description:    "Consequat enim velit magna ad sit. Lorem mollit proident est id aliqua ea ea est aliquip magna.",
-			Priority:       "high",
-			Status:         "pending",
-			SubmitterID:    23,
-			AssigneeID:     56,
-			OrganizationID: 118,
-			Tags:           []string{"Missouri", "Alabama", "Virginia", "Virgin Islands"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-18T03:33:30 -10:00",
-			Via:            "chat",
-		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("17951590-6a78-49e8-8e45-1d4326ba49cc")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/17951590-6a78-49e8-8e45-1d4326ba49cc.json",
-			ExternalID:     uuid.Must(uuid.Parse("f77cae39-867c-4890-9696-b4d5c7748fa3")),
-			CreatedAt:      "2016-06-28T03:29:34 -10:00",
-			Type:           "incident",
-			Subject:        "A Nuisance in Kenya",
-			Description:    "Magna est nostrud commodo sint aliqua labore deserunt. Do est dolore enim duis non culpa fugiat laboris exercitation et.",
-			Priority:       "normal",
-			Status:         "open",
-			SubmitterID:    53,
-			OrganizationID: 118,
-			Tags: []string{
-				"District Of Columbia", "Wisconsin", "Illinois",
-				"Fédératéd Statés Of Micronésia",
-			},
-			HasIncidents: true,
-			DueAt:        "2016-08-16T09:10:29 -10:00",
-			Via:          "chat",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("3d0d0ce2-6d1b-4f8d-a743-3863aeb29aab")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/3d0d0ce2-6d1b-4f8d-a743-3863aeb29aab.json",
+				ExternalID:     uuid.Must(uuid.Parse("a6793810-40a3-486c-a002-bd43b384c759")),
+				CreatedAt:      models.MustParseTimestamp("2016-06-07T12:05:31 -10:00"),
+				Type:           "task",
+				Subject:        "A Problem in Pitcairn",
+				Description:    "Reprehenderit eiusmod dolore deserunt deserunt nostrud labore amet exercitation laborum. Consequat enim nostrud in id voluptate esse nostrud deserunt quis culpa cillum nulla ullamco nulla.",
+				Priority:       "urgent",
+				Status:         "pending",
+				SubmitterID:    41,
+				AssigneeID:     64,
+				OrganizationID: 118,
+				Tags:           []string{"Guam", "Colorado", "Washington", "Wyoming"},
+				DueAt:          models.MustParseTimestamp("2016-08-07T05:39:40 -10:00"),
+				Via:            "chat",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("3d0d0ce2-6d1b-4f8d-a743-3863aeb29aab")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/3d0d0ce2-6d1b-4f8d-a743-3863aeb29aab.json",
-			ExternalID:     uuid.Must(uuid.Parse("a6793810-40a3-486c-a002-bd43b384c759")),
-			CreatedAt:      "2016-06-07T12:05:31 -10:00",
-			Type:           "task",
-			Subject:        "A Problem in Pitcairn",
-			Description:    "Reprehenderit eiusmod dolore deserunt deserunt nostrud labore amet exercitation laborum. Consequat enim nostrud in id voluptate esse nostrud deserunt quis culpa cillum nulla ullamco nulla.",
-			Priority:       "urgent",
-			Status:         "pending",
-			SubmitterID:    41,
-			AssigneeID:     64,
-			OrganizationID: 118,
-			Tags:           []string{"Guam", "Colorado", "Washington", "Wyoming"},
-			DueAt:          "2016-08-07T05:39:40 -10:00",
-			Via:            "chat",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("17951590-6a78-49e8-8e45-1d4326ba49cc")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/17951590-6a78-49e8-8e45-1d4326ba49cc.json",
+				ExternalID:     uuid.Must(uuid.Parse("f77cae39-867c-4890-9696-b4d5c7748fa3")),
+				CreatedAt:      models.MustParseTimestamp("2016-06-28T03:29:34 -10:00"),
+				Type:           "incident",
+				Subject:        "A Nuisance in Kenya",
+				Description:    "Magna est nostrud commodo sint aliqua labore deserunt. Do est dolore enim duis non culpa fugiat laboris exercitation et.",
+				Priority:       "normal",
+				Status:         "open",
+				SubmitterID:    53,
+				OrganizationID: 118,
+				Tags:           []string{"District Of Columbia", "Wisconsin", "Illinois", "Fédératéd Statés Of Micronésia"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-16T09:10:29 -10:00"),
+				Via:            "chat",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("4c5a405d-0805-4d8b-ac48-2a3d7f3816e4")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/4c5a405d-0805-4d8b-ac48-2a3d7f3816e4.json",
-			ExternalID:     uuid.Must(uuid.Parse("3e690d6e-b322-4d56-b72a-a18cda46f717")),
-			CreatedAt:      "2016-03-08T04:22:35 -11:00",
-			Type:           "incident",
-			Subject:        "A Drama in Haiti",
-			Description:    "Enim commodo officia laborum veniam anim nisi occaecat. Lorem voluptate cupidatat do eu irure reprehenderit culpa.",
-			Priority:       "high",
-			Status:         "hold",
-			SubmitterID:    74,
-			AssigneeID:     16,
-			OrganizationID: 118,
-			Tags:           []string{"Massachusetts", "New York", "Minnesota", "New Jersey"},
-			DueAt:          "2016-08-06T09:42:11 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("92e5d8f0-853a-4f56-b7fb-b0582e6b1c79")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/92e5d8f0-853a-4f56-b7fb-b0582e6b1c79.json",
+				ExternalID:     uuid.Must(uuid.Parse("39e2b2fa-9d90-4390-beb5-2bade85ce5ba")),
+				CreatedAt:      models.MustParseTimestamp("2016-01-06T09:27:57 -11:00"),
+				Type:           "incident",
+				Subject:        "A Drama in Nepal",
+				Description:    "Et occaecat elit enim tempor ipsum. Sint sit proident sit ipsum cillum voluptate ipsum nostrud officia sint exercitation reprehenderit id eu.",
+				Priority:       "high",
+				Status:         "pending",
+				SubmitterID:    8,
+				AssigneeID:     72,
+				OrganizationID: 118,
+				Tags:           []string{"Kentucky", "North Carolina", "South Carolina", "Indiana"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-05T09:42:07 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("53867869-0db0-4b8d-9d6c-9d1c0af4e693")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/53867869-0db0-4b8d-9d6c-9d1c0af4e693.json",
-			ExternalID:     uuid.Must(uuid.Parse("d3b44197-5e5f-4dee-82de-bda68efb6210")),
-			CreatedAt:      "2016-05-14T09:19:56 -10:00",
-			Type:           "task",
-			Subject:        "A Drama in Gabon",
-			Description:    "Eu anim laborum enim voluptate ex minim quis magna culpa occaecat qui amet anim. Consectetur adipisicing sunt est fugiat cillum eiusmod elit nostrud cupidatat culpa esse eiusmod.",
-			Priority:       "urgent",
-			Status:         "solved",
-			SubmitterID:    51,
-			AssigneeID:     5,
-			OrganizationID: 118,
-			Tags:           []string{"Utah", "Hawaii", "Alaska", "Maryland"},
-			DueAt:          "2016-08-14T06:11:52 -10:00",
-			Via:            "web",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("4c5a405d-0805-4d8b-ac48-2a3d7f3816e4")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/4c5a405d-0805-4d8b-ac48-2a3d7f3816e4.json",
+				ExternalID:     uuid.Must(uuid.Parse("3e690d6e-b322-4d56-b72a-a18cda46f717")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-08T04:22:35 -11:00"),
+				Type:           "incident",
+				Subject:        "A Drama in Haiti",
+				Description:    "Enim commodo officia laborum veniam anim nisi occaecat. Lorem voluptate cupidatat do eu irure reprehenderit culpa.",
+				Priority:       "high",
+				Status:         "hold",
+				SubmitterID:    74,
+				AssigneeID:     16,
+				OrganizationID: 118,
+				Tags:           []string{"Massachusetts", "New York", "Minnesota", "New Jersey"},
+				DueAt:          models.MustParseTimestamp("2016-08-06T09:42:11 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("7382ad0e-dea7-4c8d-b38f-cbbf016f2598")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/7382ad0e-dea7-4c8d-b38f-cbbf016f2598.json",
-			ExternalID:     uuid.Must(uuid.Parse("6d3b0e05-6013-4513-9913-0bb6a0f66ef7")),
-			CreatedAt:      "2016-03-31T03:16:52 -11:00",
-			Type:           "task",
-			Subject:        "A Problem in American Samoa",
-			Description:    "Excepteur dolor in commodo minim irure laboris. In incididunt mollit veniam pariatur ullamco laborum ullamco aliqua do fugiat Lorem.",
-			Priority:       "high",
-			Status:         "closed",
-			SubmitterID:    35,
-			AssigneeID:     64,
-			OrganizationID: 118,
-			Tags:           []string{"Missouri", "Alabama", "Virginia", "Virgin Islands"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-06T08:36:17 -10:00",
-			Via:            "chat",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("8d7b4d51-ef95-4923-9ab8-42332ab2188d")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/8d7b4d51-ef95-4923-9ab8-42332ab2188d.json",
+				ExternalID:     uuid.Must(uuid.Parse("c0a785cf-b0e0-4627-acb6-97adac4b7be6")),
+				CreatedAt:      models.MustParseTimestamp("2016-05-30T02:40:22 -10:00"),
+				Type:           "question",
+				Subject:        "A Catastrophe in Malta",
+				Description:    "Est consequat elit do do id laborum ad enim sit nostrud id eiusmod. Labore tempor velit cupidatat aliquip excepteur anim aliquip aliquip.",
+				Priority:       "high",
+				Status:         "pending",
+				SubmitterID:    3,
+				AssigneeID:     8,
+				OrganizationID: 118,
+				Tags:           []string{"Virginia", "Virgin Islands", "Maine", "West Virginia"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-12T02:41:31 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("87db32c5-76a3-4069-954c-7d59c6c21de0")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/87db32c5-76a3-4069-954c-7d59c6c21de0.json",
-			ExternalID:     uuid.Must(uuid.Parse("1c61056c-a5ad-478a-9fd6-38889c3cd728")),
-			CreatedAt:      "2016-07-06T11:16:50 -10:00",
-			Type:           "problem",
-			Subject:        "A Problem in Morocco",
-			Description:    "Sit culpa non magna anim. Ea velit qui nostrud eiusmod laboris dolor adipisicing quis deserunt elit amet.",
-			Priority:       "urgent",
-			Status:         "solved",
-			SubmitterID:    14,
-			AssigneeID:     7,
-			OrganizationID: 118,
-			Tags:           []string{"Texas", "Nevada", "Oregon", "Arizona"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-19T07:40:17 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("945ce2d3-3edc-4936-8d51-e59e74cf917a")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/945ce2d3-3edc-4936-8d51-e59e74cf917a.json",
+				ExternalID:     uuid.Must(uuid.Parse("5c741d66-cdd4-4d20-bb95-a3948217bf2c")),
+				CreatedAt:      models.MustParseTimestamp("2016-04-23T05:47:03 -10:00"),
+				Type:           "task",
+				Subject:        "A Drama in Guinea",
+				Description:    "Esse Lorem qui cillum amet enim sint aute duis veniam non. Esse irure sit qui non amet reprehenderit ullamco tempor duis exercitation excepteur.",
+				Priority:       "urgent",
+				Status:         "hold",
+				SubmitterID:    70,
+				AssigneeID:     32,
+				OrganizationID: 118,
+				Tags:           []string{"American Samoa", "Northern Mariana Islands", "Puerto Rico", "Idaho"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-07-31T05:29:05 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("8d7b4d51-ef95-4923-9ab8-42332ab2188d")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/8d7b4d51-ef95-4923-9ab8-42332ab2188d.json",
-			ExternalID:     uuid.Must(uuid.Parse("c0a785cf-b0e0-4627-acb6-97adac4b7be6")),
-			CreatedAt:      "2016-05-30T02:40:22 -10:00",
-			Type:           "question",
-			Subject:        "A Catastrophe in Malta",
-			Description:    "Est consequat elit do do id laborum ad enim sit nostrud id eiusmod. Labore tempor velit cupidatat aliquip excepteur anim aliquip aliquip.",
-			Priority:       "high",
-			Status:         "pending",
-			SubmitterID:    3,
-			AssigneeID:     8,
-			OrganizationID: 118,
-			Tags:           []string{"Virginia", "Virgin Islands", "Maine", "West Virginia"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-12T02:41:31 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("0ebe753c-9c78-458a-817f-3993780bedbf")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/0ebe753c-9c78-458a-817f-3993780bedbf.json",
+				ExternalID:     uuid.Must(uuid.Parse("537ad752-9056-42c9-86db-f0bdf06d3c10")),
+				CreatedAt:      models.MustParseTimestamp("2016-05-19T12:19:56 -10:00"),
+				Type:           "problem",
+				Subject:        "A Nuisance in Seychelles",
+				Description:    "Consequat enim velit magna ad sit. Lorem mollit proident est id aliqua ea ea est aliquip magna.",
+				Priority:       "high",
+				Status:         "pending",
+				SubmitterID:    23,
+				AssigneeID:     56,
+				OrganizationID: 118,
+				Tags:           []string{"Missouri", "Alabama", "Virginia", "Virgin Islands"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-18T03:33:30 -10:00"),
+				Via:            "chat",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("92e5d8f0-853a-4f56-b7fb-b0582e6b1c79")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/92e5d8f0-853a-4f56-b7fb-b0582e6b1c79.json",
-			ExternalID:     uuid.Must(uuid.Parse("39e2b2fa-9d90-4390-beb5-2bade85ce5ba")),
-			CreatedAt:      "2016-01-06T09:27:57 -11:00",
-			Type:           "incident",
-			Subject:        "A Drama in Nepal",
-			Description:    "Et occaecat elit enim tempor ipsum. Sint sit proident sit ipsum cillum voluptate ipsum nostrud officia sint exercitation reprehenderit id eu.",
-			Priority:       "high",
-			Status:         "pending",
-			SubmitterID:    8,
-			AssigneeID:     72,
-			OrganizationID: 118,
-			Tags:           []string{"Kentucky", "North Carolina", "South Carolina", "Indiana"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-05T09:42:07 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("ad49f154-2ceb-4052-9129-ddc6d4b7e479")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/ad49f154-2ceb-4052-9129-ddc6d4b7e479.json",
+				ExternalID:     uuid.Must(uuid.Parse("8a57c17a-c7bc-4b1c-bfad-eec83f4a791d")),
+				CreatedAt:      models.MustParseTimestamp("2016-05-17T08:32:44 -10:00"),
+				Type:           "question",
+				Subject:        "A Problem in Kyrgyzstan",
+				Description:    "Pariatur eu ipsum esse qui. Quis minim ea deserunt enim do cupidatat velit aliqua qui duis pariatur velit consectetur.",
+				Priority:       "high",
+				Status:         "closed",
+				SubmitterID:    3,
+				AssigneeID:     31,
+				OrganizationID: 118,
+				Tags:           []string{"Georgia", "Tennessee", "Mississippi", "Marshall Islands"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-07-31T02:59:08 -10:00"),
+				Via:            "voice",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("945ce2d3-3edc-4936-8d51-e59e74cf917a")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/945ce2d3-3edc-4936-8d51-e59e74cf917a.json",
-			ExternalID:     uuid.Must(uuid.Parse("5c741d66-cdd4-4d20-bb95-a3948217bf2c")),
-			CreatedAt:      "2016-04-23T05:47:03 -10:00",
-			Type:           "task",
-			Subject:        "A Drama in Guinea",
-			Description:    "Esse Lorem qui cillum amet enim sint aute duis veniam non. Esse irure sit qui non amet reprehenderit ullamco tempor duis exercitation excepteur.",
-			Priority:       "urgent",
-			Status:         "hold",
-			SubmitterID:    70,
-			AssigneeID:     32,
-			OrganizationID: 118,
-			Tags:           []string{"American Samoa", "Northern Mariana Islands", "Puerto Rico", "Idaho"},
-			HasIncidents:   true,
-			DueAt:          "2016-07-31T05:29:05 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("53867869-0db0-4b8d-9d6c-9d1c0af4e693")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/53867869-0db0-4b8d-9d6c-9d1c0af4e693.json",
+				ExternalID:     uuid.Must(uuid.Parse("d3b44197-5e5f-4dee-82de-bda68efb6210")),
+				CreatedAt:      models.MustParseTimestamp("2016-05-14T09:19:56 -10:00"),
+				Type:           "task",
+				Subject:        "A Drama in Gabon",
+				Description:    "Eu anim laborum enim voluptate ex minim quis magna culpa occaecat qui amet anim. Consectetur adipisicing sunt est fugiat cillum eiusmod elit nostrud cupidatat culpa esse eiusmod.",
+				Priority:       "urgent",
+				Status:         "solved",
+				SubmitterID:    51,
+				AssigneeID:     5,
+				OrganizationID: 118,
+				Tags:           []string{"Utah", "Hawaii", "Alaska", "Maryland"},
+				DueAt:          models.MustParseTimestamp("2016-08-14T06:11:52 -10:00"),
+				Via:            "web",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("ad49f154-2ceb-4052-9129-ddc6d4b7e479")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/ad49f154-2ceb-4052-9129-ddc6d4b7e479.json",
-			ExternalID:     uuid.Must(uuid.Parse("8a57c17a-c7bc-4b1c-bfad-eec83f4a791d")),
-			CreatedAt:      "2016-05-17T08:32:44 -10:00",
-			Type:           "question",
-			Subject:        "A Problem in Kyrgyzstan",
-			Description:    "Pariatur eu ipsum esse qui. Quis minim ea deserunt enim do cupidatat velit aliqua qui duis pariatur velit consectetur.",
-			Priority:       "high",
-			Status:         "closed",
-			SubmitterID:    3,
-			AssigneeID:     31,
-			OrganizationID: 118,
-			Tags:           []string{"Georgia", "Tennessee", "Mississippi", "Marshall Islands"},
-			HasIncidents:   true,
-			DueAt:          "2016-07-31T02:59:08 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("7382ad0e-dea7-4c8d-b38f-cbbf016f2598")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/7382ad0e-dea7-4c8d-b38f-cbbf016f2598.json",
+				ExternalID:     uuid.Must(uuid.Parse("6d3b0e05-6013-4513-9913-0bb6a0f66ef7")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-31T03:16:52 -11:00"),
+				Type:           "task",
+				Subject:        "A Problem in American Samoa",
+				Description:    "Excepteur dolor in commodo minim irure laboris. In incididunt mollit veniam pariatur ullamco laborum ullamco aliqua do fugiat Lorem.",
+				Priority:       "high",
+				Status:         "closed",
+				SubmitterID:    35,
+				AssigneeID:     64,
+				OrganizationID: 118,
+				Tags:           []string{"Missouri", "Alabama", "Virginia", "Virgin Islands"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-06T08:36:17 -10:00"),
+				Via:            "chat",
+			},
 		},
-		&models.User{
-			ID:             59,
-			URL:            "http://initech.zendesk.com/api/v2/users/59.json",
-			ExternalID:     uuid.Must(uuid.Parse("4acd4eb0-9168-4270-b09f-09600a05b0b2")),
-			Name:           "Key Mendez",
-			Alias:          "Mr Lucile",
-			CreatedAt:      "2016-04-23T12:00:11 -10:00",
-			Locale:         "zh-CN",
-			Timezone:       "Nigeria",
-			LastLoginAt:    "2014-06-03T02:26:28 -10:00",
-			Email:          "lucilemendez@flotonic.com",
-			Phone:          "8774-883-991",
-			Signature:      "Don't Worry Be Happy!",
-			OrganizationID: 118,
-			Tags:           []string{"Rockingham", "Waikele", "Masthope", "Oceola"},
-			Role:           "agent",
+		&models.RelatedModel{
+			Relation: "users",
+			Model: &models.User{
+				ID:                   49,
+				URL:                  "http://initech.zendesk.com/api/v2/users/49.json",
+				ExternalID:           uuid.Must(uuid.Parse("4bd5e757-c0cd-445b-b702-ee3ed794f6c4")),
+				Name:                 "Faulkner Holcomb",
+				Alias:                "Miss Jody",
+				CreatedAt:            models.MustParseTimestamp("2016-05-12T08:39:30 -10:00"),
+				Active:               true,
+				Shared:               true,
+				Locale:               "zh-CN",
+				Timezone:             "Antigua and Barbuda",
+				LastLoginAt:          models.MustParseTimestamp("2014-12-04T12:51:36 -11:00"),
+				Email:                "jodyholcomb@flotonic.com",
+				Phone:                "9255-943-719",
+				Signature:            "Don't Worry Be Happy!",
+				OrganizationID:       118,
+				Tags:                 []string{"Hanover", "Woodlake", "Saticoy", "Hinsdale"},
+				Suspended:            true,
+				Role:                 "end-user",
+				SubmittedTicketCount: 2,
+				AssignedTicketCount:  2,
+			},
 		},
-		&models.User{
-			ID:             49,
-			URL:            "http://initech.zendesk.com/api/v2/users/49.json",
-			ExternalID:     uuid.Must(uuid.Parse("4bd5e757-c0cd-445b-b702-ee3ed794f6c4")),
-			Name:           "Faulkner Holcomb",
-			Alias:          "Miss Jody",
-			CreatedAt:      "2016-05-12T08:39:30 -10:00",
-			Active:         true,
-			Shared:         true,
-			Locale:         "zh-CN",
-			Timezone:       "Antigua and Barbuda",
-			LastLoginAt:    "2014-12-04T12:51:36 -11:00",
-			Email:          "jodyholcomb@flotonic.com",
-			Phone:          "9255-943-719",
-			Signature:      "Don't Worry Be Happy!",
-			OrganizationID: 118,
-			Tags:           []string{"Hanover", "Woodlake", "Saticoy", "Hinsdale"},
-			Suspended:      true,
-			Role:           "end-user",
+		&models.RelatedModel{
+			Relation: "users",
+			Model: &models.User{
+				ID:                   59,
+				URL:                  "http://initech.zendesk.com/api/v2/users/59.json",
+				ExternalID:           uuid.Must(uuid.Parse("4acd4eb0-9168-4270-b09f-09600a05b0b2")),
+				Name:                 "Key Mendez",
+				Alias:                "Mr Lucile",
+				CreatedAt:            models.MustParseTimestamp("2016-04-23T12:00:11 -10:00"),
+				Locale:               "zh-CN",
+				Timezone:             "Nigeria",
+				LastLoginAt:          models.MustParseTimestamp("2014-06-03T02:26:28 -10:00"),
+				Email:                "lucilemendez@flotonic.com",
+				Phone:                "8774-883-991",
+				Signature:            "Don't Worry Be Happy!",
+				OrganizationID:       118,
+				Tags:                 []string{"Rockingham", "Waikele", "Masthope", "Oceola"},
+				Role:                 "agent",
+				SubmittedTicketCount: 4,
+				AssignedTicketCount:  4,
+			},
 		},
 	}
 
@@ -259,7 +308,7 @@ var (
 			ID:             uuid.Must(uuid.Parse("6aac0369-a7e5-4417-8b50-92528ef485d3")),
 			URL:            "http://initech.zendesk.com/api/v2/tickets/6aac0369-a7e5-4417-8b50-92528ef485d3.json",
 			ExternalID:     uuid.Must(uuid.Parse("0c2ba6c6-ea9a-4a58-ada4-bc72f3b9ff39")),
-			CreatedAt:      "2016-06-15T12:03:55 -10:00",
+			CreatedAt:      models.MustParseTimestamp("2016-06-15T12:03:55 -10:00"),
 			Type:           "question",
 			Subject:        "A Nuisance in Latvia",
 			Description:    "Laboris laborum culpa sit culpa minim ad laborum Lorem laboris aliqua tempor. Aliqua sit nisi deserunt eu quis ipsum incididunt aute excepteur cillum.",
@@ -269,353 +318,522 @@ var (
 			AssigneeID:     29,
 			OrganizationID: 113,
 			Tags:           []string{"Washington", "Wyoming", "Ohio", "Pennsylvania"},
-			DueAt:          "2016-08-16T05:52:08 -10:00",
+			DueAt:          models.MustParseTimestamp("2016-08-16T05:52:08 -10:00"),
 			Via:            "chat",
+			Overdue:        true,
+		},
+		&models.RelatedModel{
+			Relation: "submitter",
+			Model: &models.User{
+				ID:                   50,
+				URL:                  "http://initech.zendesk.com/api/v2/users/50.json",
+				ExternalID:           uuid.Must(uuid.Parse("e1378651-f998-4181-8b1b-35e99a30b900")),
+				Name:                 "Daniel Agüilar",
+				Alias:                "Mr Aüstin",
+				CreatedAt:            models.MustParseTimestamp("2016-04-07T12:19:09 -10:00"),
+				Active:               true,
+				Locale:               "de-CH",
+				Timezone:             "Malawi",
+				LastLoginAt:          models.MustParseTimestamp("2016-03-08T07:57:37 -11:00"),
+				Email:                "austinaguilar@flotonic.com",
+				Phone:                "8864-732-323",
+				Signature:            "Don't Worry Be Happy!",
+				OrganizationID:       107,
+				Tags:                 []string{"Kaka", "Abrams", "Genoa", "Yettem"},
+				Role:                 "admin",
+				SubmittedTicketCount: 4,
+				AssignedTicketCount:  4,
+			},
+		},
+		&models.RelatedModel{
+			Relation: "assignee",
+			Model: &models.User{
+				ID:                   29,
+				URL:                  "http://initech.zendesk.com/api/v2/users/29.json",
+				ExternalID:           uuid.Must(uuid.Parse("5cf7c032-b3cb-4c87-afa1-57fc9f94e9a1")),
+				Name:                 "Herrera Norman",
+				Alias:                "Mr Vance",
+				CreatedAt:            models.MustParseTimestamp("2016-03-17T06:09:57 -11:00"),
+				Shared:               true,
+				Locale:               "en-AU",
+				Timezone:             "Zimbabwe",
+				LastLoginAt:          models.MustParseTimestamp("2016-05-17T03:03:05 -10:00"),
+				Email:                "vancenorman@flotonic.com",
+				Phone:                "9444-743-342",
+				Signature:            "Don't Worry Be Happy!",
+				OrganizationID:       101,
+				Tags:                 []string{"Tilden", "Layhill", "Franklin", "Allensworth"},
+				Role:                 "end-user",
+				SubmittedTicketCount: 3,
+				AssignedTicketCount:  2,
+			},
+		},
+		&models.RelatedModel{
+			Relation: "organization",
+			Model: &models.Organization{
+				ID:            113,
+				URL:           "http://initech.zendesk.com/api/v2/organizations/113.json",
+				ExternalID:    uuid.Must(uuid.Parse("67d9dbdb-a9c6-4a30-a003-202de05d09e2")),
+				Name:          "Noralex",
+				DomainNames:   []string{"artiq.com", "mazuda.com", "surelogic.com", "fuelworks.com"},
+				CreatedAt:     models.MustParseTimestamp("2016-04-09T08:45:29 -10:00"),
+				Details:       "MegaCörp",
+				SharedTickets: true,
+				Tags:          []string{"Maldonado", "Hebert", "Poole", "Mcleod"},
+				UserCount:     4,
+				TicketCount:   7,
+			},
 		},
 	}
 
 	usersInOrg118Results = []models.Model{
 		&models.User{
-			ID:             59,
-			URL:            "http://initech.zendesk.com/api/v2/users/59.json",
-			ExternalID:     uuid.Must(uuid.Parse("4acd4eb0-9168-4270-b09f-09600a05b0b2")),
-			Name:           "Key Mendez",
-			Alias:          "Mr Lucile",
-			CreatedAt:      "2016-04-23T12:00:11 -10:00",
-			Locale:         "zh-CN",
-			Timezone:       "Nigeria",
-			LastLoginAt:    "2014-06-03T02:26:28 -10:00",
-			Email:          "lucilemendez@flotonic.com",
-			Phone:          "8774-883-991",
-			Signature:      "Don't Worry Be Happy!",
-			OrganizationID: 118,
-			Tags:           []string{"Rockingham", "Waikele", "Masthope", "Oceola"},
-			Role:           "agent",
+			ID:                   49,
+			URL:                  "http://initech.zendesk.com/api/v2/users/49.json",
+			ExternalID:           uuid.Must(uuid.Parse("4bd5e757-c0cd-445b-b702-ee3ed794f6c4")),
+			Name:                 "Faulkner Holcomb",
+			Alias:                "Miss Jody",
+			CreatedAt:            models.MustParseTimestamp("2016-05-12T08:39:30 -10:00"),
+			Active:               true,
+			Shared:               true,
+			Locale:               "zh-CN",
+			Timezone:             "Antigua and Barbuda",
+			LastLoginAt:          models.MustParseTimestamp("2014-12-04T12:51:36 -11:00"),
+			Email:                "jodyholcomb@flotonic.com",
+			Phone:                "9255-943-719",
+			Signature:            "Don't Worry Be Happy!",
+			OrganizationID:       118,
+			Tags:                 []string{"Hanover", "Woodlake", "Saticoy", "Hinsdale"},
+			Suspended:            true,
+			Role:                 "end-user",
+			SubmittedTicketCount: 2,
+			AssignedTicketCount:  2,
+		},
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("674a19a1-c330-45fb-8b61-b4d77ba87130")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/674a19a1-c330-45fb-8b61-b4d77ba87130.json",
+				ExternalID:     uuid.Must(uuid.Parse("050ea8ce-251c-44c8-b71c-535dd9072a74")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-07T08:24:53 -11:00"),
+				Type:           "task",
+				Subject:        "A Drama in St. Pierre and Miquelon",
+				Description:    "Incididunt exercitation voluptate eu laborum proident Lorem minim pariatur. Lorem culpa amet Lorem Lorem commodo anim deserunt do consectetur sunt.",
+				Priority:       "low",
+				Status:         "open",
+				SubmitterID:    49,
+				AssigneeID:     14,
+				OrganizationID: 109,
+				Tags:           []string{"Connecticut", "Arkansas", "Missouri", "Alabama"},
+				DueAt:          models.MustParseTimestamp("2016-08-15T06:13:11 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.User{
-			ID:             49,
-			URL:            "http://initech.zendesk.com/api/v2/users/49.json",
-			ExternalID:     uuid.Must(uuid.Parse("4bd5e757-c0cd-445b-b702-ee3ed794f6c4")),
-			Name:           "Faulkner Holcomb",
-			Alias:          "Miss Jody",
-			CreatedAt:      "2016-05-12T08:39:30 -10:00",
-			Active:         true,
-			Shared:         true,
-			Locale:         "zh-CN",
-			Timezone:       "Antigua and Barbuda",
-			LastLoginAt:    "2014-12-04T12:51:36 -11:00",
-			Email:          "jodyholcomb@flotonic.com",
-			Phone:          "9255-943-719",
-			Signature:      "Don't Worry Be Happy!",
-			OrganizationID: 118,
-			Tags:           []string{"Hanover", "Woodlake", "Saticoy", "Hinsdale"},
-			Suspended:      true,
-			Role:           "end-user",
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("6e77bbf1-5fc7-4f41-aeb1-74f8730f974b")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/6e77bbf1-5fc7-4f41-aeb1-74f8730f974b.json",
+				ExternalID:     uuid.Must(uuid.Parse("3ce8b7f5-952b-485a-a6c3-8d5259d3850a")),
+				CreatedAt:      models.MustParseTimestamp("2016-06-24T07:57:38 -10:00"),
+				Type:           "problem",
+				Subject:        "A Problem in Guatemala",
+				Description:    "Ex labore dolor commodo magna ex pariatur sunt amet ad quis duis laborum. Fugiat anim non esse eu sunt elit.",
+				Priority:       "high",
+				Status:         "open",
+				SubmitterID:    49,
+				AssigneeID:     26,
+				OrganizationID: 119,
+				Tags:           []string{"Texas", "Nevada", "Oregon", "Arizona"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-01T11:20:58 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("04ae0b9c-ded7-44c4-899c-d7348fc17b45")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/04ae0b9c-ded7-44c4-899c-d7348fc17b45.json",
-			ExternalID:     uuid.Must(uuid.Parse("bc809a7d-8511-4a4f-bb44-c9b984a8ab78")),
-			CreatedAt:      "2016-02-11T12:47:58 -11:00",
-			Type:           "task",
-			Subject:        "A Catastrophe in Cape Verde",
-			Description:    "Excepteur laboris commodo pariatur aute esse exercitation officia ea aliqua consectetur culpa eiusmod cupidatat. Dolor minim consequat laborum aliqua.",
-			Priority:       "normal",
-			Status:         "pending",
-			SubmitterID:    40,
-			AssigneeID:     59,
-			OrganizationID: 109,
-			Tags: []string{
-				"District Of Columbia", "Wisconsin", "Illinois",
-				"Fédératéd Statés Of Micronésia",
-			},
-			DueAt: "2016-08-10T12:49:29 -10:00",
-			Via:   "web",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("e33110bb-fd7b-4983-987a-4172a9e24919")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/e33110bb-fd7b-4983-987a-4172a9e24919.json",
+				ExternalID:     uuid.Must(uuid.Parse("8b12ee7a-9b59-4b84-af4e-6f2682bcdddf")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-14T01:50:07 -11:00"),
+				Type:           "problem",
+				Subject:        "A Catastrophe in US Minor Outlying Islands",
+				Description:    "Nisi Lorem labore cillum laborum dolor voluptate incididunt. Cupidatat aliqua in mollit adipisicing ullamco qui do fugiat.",
+				Priority:       "high",
+				Status:         "closed",
+				SubmitterID:    29,
+				AssigneeID:     49,
+				OrganizationID: 122,
+				Tags:           []string{"Georgia", "Tennessee", "Mississippi", "Marshall Islands"},
+				DueAt:          models.MustParseTimestamp("2016-08-05T07:03:16 -10:00"),
+				Via:            "web",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("25cb699f-a5dd-45d8-9bc1-9c4b7d096946")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/25cb699f-a5dd-45d8-9bc1-9c4b7d096946.json",
-			ExternalID:     uuid.Must(uuid.Parse("e85c7f58-59ed-4e05-9734-eb2a3aa92fa8")),
-			CreatedAt:      "2016-04-03T04:05:26 -10:00",
-			Type:           "problem",
-			Subject:        "A Problem in Syria",
-			Description:    "Consequat Lorem esse non et labore. Eiusmod veniam amet est anim minim laborum anim qui ipsum magna velit pariatur tempor.",
-			Priority:       "high",
-			Status:         "solved",
-			SubmitterID:    59,
-			AssigneeID:     48,
-			OrganizationID: 102,
-			Tags:           []string{"American Samoa", "Northern Mariana Islands", "Puerto Rico", "Idaho"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-10T07:23:05 -10:00",
-			Via:            "chat",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("55135930-9f1f-43df-a9fd-2105fff74578")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/55135930-9f1f-43df-a9fd-2105fff74578.json",
+				ExternalID:     uuid.Must(uuid.Parse("f0dc9986-6552-4a84-84ba-e9c67453a55a")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-24T08:06:32 -11:00"),
+				Type:           "problem",
+				Subject:        "A Problem in Mexico",
+				Description:    "Exercitation in pariatur ex est dolore duis et do excepteur ullamco commodo reprehenderit. Exercitation aute excepteur dolore laboris consequat ullamco irure id ipsum cillum esse.",
+				Priority:       "normal",
+				Status:         "open",
+				SubmitterID:    74,
+				AssigneeID:     49,
+				OrganizationID: 116,
+				Tags:           []string{"Utah", "Hawaii", "Alaska", "Maryland"},
+				DueAt:          models.MustParseTimestamp("2016-08-14T07:14:25 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("55135930-9f1f-43df-a9fd-2105fff74578")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/55135930-9f1f-43df-a9fd-2105fff74578.json",
-			ExternalID:     uuid.Must(uuid.Parse("f0dc9986-6552-4a84-84ba-e9c67453a55a")),
-			CreatedAt:      "2016-03-24T08:06:32 -11:00",
-			Type:           "problem",
-			Subject:        "A Problem in Mexico",
-			Description:    "Exercitation in pariatur ex est dolore duis et do excepteur ullamco commodo reprehenderit. Exercitation aute excepteur dolore laboris consequat ullamco irure id ipsum cillum esse.",
-			Priority:       "normal",
-			Status:         "open",
-			SubmitterID:    74,
-			AssigneeID:     49,
-			OrganizationID: 116,
-			Tags:           []string{"Utah", "Hawaii", "Alaska", "Maryland"},
-			DueAt:          "2016-08-14T07:14:25 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "organization",
+			Model: &models.Organization{
+				ID:          118,
+				URL:         "http://initech.zendesk.com/api/v2/organizations/118.json",
+				ExternalID:  uuid.Must(uuid.Parse("6970300e-f211-4c01-a538-70b4464a1d84")),
+				Name:        "Limozen",
+				DomainNames: []string{"otherway.com", "rodeomad.com", "suremax.com", "fishland.com"},
+				CreatedAt:   models.MustParseTimestamp("2016-02-11T04:24:09 -11:00"),
+				Details:     "MegaCorp",
+				Tags:        []string{"Leon", "Ferguson", "Olsen", "Walsh"},
+				UserCount:   2,
+				TicketCount: 11,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("59d803f6-a9cd-448c-a6bd-91ce9f044305")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/59d803f6-a9cd-448c-a6bd-91ce9f044305.json",
-			ExternalID:     uuid.Must(uuid.Parse("46d3d5d7-e6f1-4b30-94c7-06a5ee46a5a1")),
-			CreatedAt:      "2016-02-15T05:41:05 -11:00",
-			Type:           "task",
-			Subject:        "A Drama in Burundi",
-			Description:    "Commodo aute amet eu irure sunt deserunt nulla excepteur minim tempor cupidatat do. Fugiat magna Lorem Lorem ullamco.",
-			Priority:       "normal",
-			Status:         "open",
-			SubmitterID:    59,
-			AssigneeID:     15,
-			OrganizationID: 117,
-			Tags:           []string{"Kentucky", "North Carolina", "South Carolina", "Indiana"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-14T08:05:46 -10:00",
-			Via:            "web",
+		&models.User{
+			ID:                   59,
+			URL:                  "http://initech.zendesk.com/api/v2/users/59.json",
+			ExternalID:           uuid.Must(uuid.Parse("4acd4eb0-9168-4270-b09f-09600a05b0b2")),
+			Name:                 "Key Mendez",
+			Alias:                "Mr Lucile",
+			CreatedAt:            models.MustParseTimestamp("2016-04-23T12:00:11 -10:00"),
+			Locale:               "zh-CN",
+			Timezone:             "Nigeria",
+			LastLoginAt:          models.MustParseTimestamp("2014-06-03T02:26:28 -10:00"),
+			Email:                "lucilemendez@flotonic.com",
+			Phone:                "8774-883-991",
+			Signature:            "Don't Worry Be Happy!",
+			OrganizationID:       118,
+			Tags:                 []string{"Rockingham", "Waikele", "Masthope", "Oceola"},
+			Role:                 "agent",
+			SubmittedTicketCount: 4,
+			AssignedTicketCount:  4,
+		},
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("25cb699f-a5dd-45d8-9bc1-9c4b7d096946")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/25cb699f-a5dd-45d8-9bc1-9c4b7d096946.json",
+				ExternalID:     uuid.Must(uuid.Parse("e85c7f58-59ed-4e05-9734-eb2a3aa92fa8")),
+				CreatedAt:      models.MustParseTimestamp("2016-04-03T04:05:26 -10:00"),
+				Type:           "problem",
+				Subject:        "A Problem in Syria",
+				Description:    "Consequat Lorem esse non et labore. Eiusmod veniam amet est anim minim laborum anim qui ipsum magna velit pariatur tempor.",
+				Priority:       "high",
+				Status:         "solved",
+				SubmitterID:    59,
+				AssigneeID:     48,
+				OrganizationID: 102,
+				Tags:           []string{"American Samoa", "Northern Mariana Islands", "Puerto Rico", "Idaho"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-10T07:23:05 -10:00"),
+				Via:            "chat",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("674a19a1-c330-45fb-8b61-b4d77ba87130")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/674a19a1-c330-45fb-8b61-b4d77ba87130.json",
-			ExternalID:     uuid.Must(uuid.Parse("050ea8ce-251c-44c8-b71c-535dd9072a74")),
-			CreatedAt:      "2016-03-07T08:24:53 -11:00",
-			Type:           "task",
-			Subject:        "A Drama in St. Pierre and Miquelon",
-			Description:    "Incididunt exercitation voluptate eu laborum proident Lorem minim pariatur. Lorem culpa amet Lorem Lorem commodo anim deserunt do consectetur sunt.",
-			Priority:       "low",
-			Status:         "open",
-			SubmitterID:    49,
-			AssigneeID:     14,
-			OrganizationID: 109,
-			Tags:           []string{"Connecticut", "Arkansas", "Missouri", "Alabama"},
-			DueAt:          "2016-08-15T06:13:11 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("8ea53283-5b36-4328-9a78-f261ee90f44b")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/8ea53283-5b36-4328-9a78-f261ee90f44b.json",
+				ExternalID:     uuid.Must(uuid.Parse("c3ac8b50-0982-462f-b2d4-b0132ac32578")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-07T03:00:54 -11:00"),
+				Type:           "task",
+				Subject:        "A Catastrophe in Sierra Leone",
+				Description:    "Elit cupidatat amet non quis Lorem elit officia commodo culpa eiusmod cupidatat. Mollit proident elit reprehenderit ea qui commodo reprehenderit consequat quis sunt.",
+				Priority:       "low",
+				Status:         "open",
+				SubmitterID:    59,
+				AssigneeID:     71,
+				OrganizationID: 124,
+				Tags:           []string{"Washington", "Wyoming", "Ohio", "Pennsylvania"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-22T08:21:08 -10:00"),
+				Via:            "chat",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("6e77bbf1-5fc7-4f41-aeb1-74f8730f974b")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/6e77bbf1-5fc7-4f41-aeb1-74f8730f974b.json",
-			ExternalID:     uuid.Must(uuid.Parse("3ce8b7f5-952b-485a-a6c3-8d5259d3850a")),
-			CreatedAt:      "2016-06-24T07:57:38 -10:00",
-			Type:           "problem",
-			Subject:        "A Problem in Guatemala",
-			Description:    "Ex labore dolor commodo magna ex pariatur sunt amet ad quis duis laborum. Fugiat anim non esse eu sunt elit.",
-			Priority:       "high",
-			Status:         "open",
-			SubmitterID:    49,
-			AssigneeID:     26,
-			OrganizationID: 119,
-			Tags:           []string{"Texas", "Nevada", "Oregon", "Arizona"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-01T11:20:58 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("dae7a200-89b8-4a43-a17d-93c8f33a2aaa")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/dae7a200-89b8-4a43-a17d-93c8f33a2aaa.json",
+				ExternalID:     uuid.Must(uuid.Parse("ada97f02-61f7-4fdf-b6b5-ae2c741259ef")),
+				CreatedAt:      models.MustParseTimestamp("2016-02-06T03:56:09 -11:00"),
+				Type:           "task",
+				Subject:        "A Problem in Ukraine",
+				Description:    "Consequat labore sunt duis proident commodo ut ex amet occaecat ut nisi fugiat. Incididunt laboris minim laboris aute excepteur veniam do excepteur duis ipsum aute duis.",
+				Priority:       "high",
+				Status:         "hold",
+				SubmitterID:    59,
+				AssigneeID:     63,
+				OrganizationID: 125,
+				Tags:           []string{"California", "Palau", "Kentucky", "North Carolina"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-21T03:47:18 -10:00"),
+				Via:            "web",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("7c67b6ed-6776-4065-bd4a-f2d9d12c33b7")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/7c67b6ed-6776-4065-bd4a-f2d9d12c33b7.json",
-			ExternalID:     uuid.Must(uuid.Parse("a429a380-84db-447b-b50f-02c09165dab2")),
-			CreatedAt:      "2016-07-03T03:05:56 -10:00",
-			Type:           "problem",
-			Subject:        "A Nuisance in Greenland",
-			Description:    "Fugiat aliquip esse elit dolore aliquip ipsum ullamco proident excepteur non duis aute labore. Est laborum ex labore quis adipisicing.",
-			Priority:       "normal",
-			Status:         "solved",
-			SubmitterID:    42,
-			AssigneeID:     59,
-			OrganizationID: 107,
-			Tags:           []string{"Oklahoma", "Louisiana", "Massachusetts", "New York"},
-			DueAt:          "2016-08-17T06:25:43 -10:00",
-			Via:            "chat",
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("59d803f6-a9cd-448c-a6bd-91ce9f044305")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/59d803f6-a9cd-448c-a6bd-91ce9f044305.json",
+				ExternalID:     uuid.Must(uuid.Parse("46d3d5d7-e6f1-4b30-94c7-06a5ee46a5a1")),
+				CreatedAt:      models.MustParseTimestamp("2016-02-15T05:41:05 -11:00"),
+				Type:           "task",
+				Subject:        "A Drama in Burundi",
+				Description:    "Commodo aute amet eu irure sunt deserunt nulla excepteur minim tempor cupidatat do. Fugiat magna Lorem Lorem ullamco.",
+				Priority:       "normal",
+				Status:         "open",
+				SubmitterID:    59,
+				AssigneeID:     15,
+				OrganizationID: 117,
+				Tags:           []string{"Kentucky", "North Carolina", "South Carolina", "Indiana"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-14T08:05:46 -10:00"),
+				Via:            "web",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("8ea53283-5b36-4328-9a78-f261ee90f44b")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/8ea53283-5b36-4328-9a78-f261ee90f44b.json",
-			ExternalID:     uuid.Must(uuid.Parse("c3ac8b50-0982-462f-b2d4-b0132ac32578")),
-			CreatedAt:      "2016-03-07T03:00:54 -11:00",
-			Type:           "task",
-			Subject:        "A Catastrophe in Sierra Leone",
-			Description:    "Elit cupidatat amet non quis Lorem elit officia commodo culpa eiusmod cupidatat. Mollit proident elit reprehenderit ea qui commodo reprehenderit consequat quis sunt.",
-			Priority:       "low",
-			Status:         "open",
-			SubmitterID:    59,
-			AssigneeID:     71,
-			OrganizationID: 124,
-			Tags:           []string{"Washington", "Wyoming", "Ohio", "Pennsylvania"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-22T08:21:08 -10:00",
-			Via:            "chat",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("7c67b6ed-6776-4065-bd4a-f2d9d12c33b7")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/7c67b6ed-6776-4065-bd4a-f2d9d12c33b7.json",
+				ExternalID:     uuid.Must(uuid.Parse("a429a380-84db-447b-b50f-02c09165dab2")),
+				CreatedAt:      models.MustParseTimestamp("2016-07-03T03:05:56 -10:00"),
+				Type:           "problem",
+				Subject:        "A Nuisance in Greenland",
+				Description:    "Fugiat aliquip esse elit dolore aliquip ipsum ullamco proident excepteur non duis aute labore. Est laborum ex labore quis adipisicing.",
+				Priority:       "normal",
+				Status:         "solved",
+				SubmitterID:    42,
+				AssigneeID:     59,
+				OrganizationID: 107,
+				Tags:           []string{"Oklahoma", "Louisiana", "Massachusetts", "New York"},
+				DueAt:          models.MustParseTimestamp("2016-08-17T06:25:43 -10:00"),
+				Via:            "chat",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("d8cf9df6-946c-4371-9e3d-50b83fa4238e")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/d8cf9df6-946c-4371-9e3d-50b83fa4238e.json",
-			ExternalID:     uuid.Must(uuid.Parse("e424507b-741b-4c3f-9ad5-811250c01880")),
-			CreatedAt:      "2016-04-03T09:49:35 -10:00",
-			Type:           "question",
-			Subject:        "A Catastrophe in Cuba",
-			Description:    "Ex Lorem nostrud nulla Lorem sunt fugiat duis magna irure adipisicing ea non. Velit eiusmod laborum aliquip ea velit amet veniam mollit mollit laborum aliqua officia.",
-			Priority:       "urgent",
-			Status:         "solved",
-			SubmitterID:    39,
-			AssigneeID:     59,
-			OrganizationID: 115,
-			Tags:           []string{"Minnesota", "New Jersey", "Texas", "Nevada"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-02T01:04:04 -10:00",
-			Via:            "chat",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("d8cf9df6-946c-4371-9e3d-50b83fa4238e")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/d8cf9df6-946c-4371-9e3d-50b83fa4238e.json",
+				ExternalID:     uuid.Must(uuid.Parse("e424507b-741b-4c3f-9ad5-811250c01880")),
+				CreatedAt:      models.MustParseTimestamp("2016-04-03T09:49:35 -10:00"),
+				Type:           "question",
+				Subject:        "A Catastrophe in Cuba",
+				Description:    "Ex Lorem nostrud nulla Lorem sunt fugiat duis magna irure adipisicing ea non. Velit eiusmod laborum aliquip ea velit amet veniam mollit mollit laborum aliqua officia.",
+				Priority:       "urgent",
+				Status:         "solved",
+				SubmitterID:    39,
+				AssigneeID:     59,
+				OrganizationID: 115,
+				Tags:           []string{"Minnesota", "New Jersey", "Texas", "Nevada"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-02T01:04:04 -10:00"),
+				Via:            "chat",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("dae7a200-89b8-4a43-a17d-93c8f33a2aaa")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/dae7a200-89b8-4a43-a17d-93c8f33a2aaa.json",
-			ExternalID:     uuid.Must(uuid.Parse("ada97f02-61f7-4fdf-b6b5-ae2c741259ef")),
-			CreatedAt:      "2016-02-06T03:56:09 -11:00",
-			Type:           "task",
-			Subject:        "A Problem in Ukraine",
-			Description:    "Consequat labore sunt duis proident commodo ut ex amet occaecat ut nisi fugiat. Incididunt laboris minim laboris aute excepteur veniam do excepteur duis ipsum aute duis.",
-			Priority:       "high",
-			Status:         "hold",
-			SubmitterID:    59,
-			AssigneeID:     63,
-			OrganizationID: 125,
-			Tags:           []string{"California", "Palau", "Kentucky", "North Carolina"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-21T03:47:18 -10:00",
-			Via:            "web",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("e804d348-2317-43b2-882a-b29d1a8acc94")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/e804d348-2317-43b2-882a-b29d1a8acc94.json",
+				ExternalID:     uuid.Must(uuid.Parse("93b1c5a2-3811-464e-9091-77432a8599fc")),
+				CreatedAt:      models.MustParseTimestamp("2016-07-05T07:25:54 -10:00"),
+				Type:           "problem",
+				Subject:        "A Nuisance in Grenada",
+				Description:    "Sunt do mollit deserunt do fugiat. Id Lorem voluptate officia do.",
+				Priority:       "urgent",
+				Status:         "solved",
+				SubmitterID:    35,
+				AssigneeID:     59,
+				OrganizationID: 113,
+				Tags:           []string{"South Dakota", "Montana", "District Of Columbia", "Wisconsin"},
+				DueAt:          models.MustParseTimestamp("2016-08-06T03:25:46 -10:00"),
+				Via:            "chat",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("e33110bb-fd7b-4983-987a-4172a9e24919")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/e33110bb-fd7b-4983-987a-4172a9e24919.json",
-			ExternalID:     uuid.Must(uuid.Parse("8b12ee7a-9b59-4b84-af4e-6f2682bcdddf")),
-			CreatedAt:      "2016-03-14T01:50:07 -11:00",
-			Type:           "problem",
-			Subject:        "A Catastrophe in US Minor Outlying Islands",
-			Description:    "Nisi Lorem labore cillum laborum dolor voluptate incididunt. Cupidatat aliqua in mollit adipisicing ullamco qui do fugiat.",
-			Priority:       "high",
-			Status:         "closed",
-			SubmitterID:    29,
-			AssigneeID:     49,
-			OrganizationID: 122,
-			Tags:           []string{"Georgia", "Tennessee", "Mississippi", "Marshall Islands"},
-			DueAt:          "2016-08-05T07:03:16 -10:00",
-			Via:            "web",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("04ae0b9c-ded7-44c4-899c-d7348fc17b45")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/04ae0b9c-ded7-44c4-899c-d7348fc17b45.json",
+				ExternalID:     uuid.Must(uuid.Parse("bc809a7d-8511-4a4f-bb44-c9b984a8ab78")),
+				CreatedAt:      models.MustParseTimestamp("2016-02-11T12:47:58 -11:00"),
+				Type:           "task",
+				Subject:        "A Catastrophe in Cape Verde",
+				Description:    "Excepteur laboris commodo pariatur aute esse exercitation officia ea aliqua consectetur culpa eiusmod cupidatat. Dolor minim consequat laborum aliqua.",
+				Priority:       "normal",
+				Status:         "pending",
+				SubmitterID:    40,
+				AssigneeID:     59,
+				OrganizationID: 109,
+				Tags:           []string{"District Of Columbia", "Wisconsin", "Illinois", "Fédératéd Statés Of Micronésia"},
+				DueAt:          models.MustParseTimestamp("2016-08-10T12:49:29 -10:00"),
+				Via:            "web",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("e804d348-2317-43b2-882a-b29d1a8acc94")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/e804d348-2317-43b2-882a-b29d1a8acc94.json",
-			ExternalID:     uuid.Must(uuid.Parse("93b1c5a2-3811-464e-9091-77432a8599fc")),
-			CreatedAt:      "2016-07-05T07:25:54 -10:00",
-			Type:           "problem",
-			Subject:        "A Nuisance in Grenada",
-			Description:    "Sunt do mollit deserunt do fugiat. Id Lorem voluptate officia do.",
-			Priority:       "urgent",
-			Status:         "solved",
-			SubmitterID:    35,
-			AssigneeID:     59,
-			OrganizationID: 113,
-			Tags:           []string{"South Dakota", "Montana", "District Of Columbia", "Wisconsin"},
-			DueAt:          "2016-08-06T03:25:46 -10:00",
-			Via:            "chat",
+		&models.RelatedModel{
+			Relation: "organization",
+			Model: &models.Organization{
+				ID:          118,
+				URL:         "http://initech.zendesk.com/api/v2/organizations/118.json",
+				ExternalID:  uuid.Must(uuid.Parse("6970300e-f211-4c01-a538-70b4464a1d84")),
+				Name:        "Limozen",
+				DomainNames: []string{"otherway.com", "rodeomad.com", "suremax.com", "fishland.com"},
+				CreatedAt:   models.MustParseTimestamp("2016-02-11T04:24:09 -11:00"),
+				Details:     "MegaCorp",
+				Tags:        []string{"Leon", "Ferguson", "Olsen", "Walsh"},
+				UserCount:   2,
+				TicketCount: 11,
+			},
 		},
 	}
 
 	userTimezoneAntiguaResults = []models.Model{
 		&models.User{
-			ID:             49,
-			URL:            "http://initech.zendesk.com/api/v2/users/49.json",
-			ExternalID:     uuid.Must(uuid.Parse("4bd5e757-c0cd-445b-b702-ee3ed794f6c4")),
-			Name:           "Faulkner Holcomb",
-			Alias:          "Miss Jody",
-			CreatedAt:      "2016-05-12T08:39:30 -10:00",
-			Active:         true,
-			Shared:         true,
-			Locale:         "zh-CN",
-			Timezone:       "Antigua and Barbuda",
-			LastLoginAt:    "2014-12-04T12:51:36 -11:00",
-			Email:          "jodyholcomb@flotonic.com",
-			Phone:          "9255-943-719",
-			Signature:      "Don't Worry Be Happy!",
-			OrganizationID: 118,
-			Tags:           []string{"Hanover", "Woodlake", "Saticoy", "Hinsdale"},
-			Suspended:      true,
-			Role:           "end-user",
+			ID:                   49,
+			URL:                  "http://initech.zendesk.com/api/v2/users/49.json",
+			ExternalID:           uuid.Must(uuid.Parse("4bd5e757-c0cd-445b-b702-ee3ed794f6c4")),
+			Name:                 "Faulkner Holcomb",
+			Alias:                "Miss Jody",
+			CreatedAt:            models.MustParseTimestamp("2016-05-12T08:39:30 -10:00"),
+			Active:               true,
+			Shared:               true,
+			Locale:               "zh-CN",
+			Timezone:             "Antigua and Barbuda",
+			LastLoginAt:          models.MustParseTimestamp("2014-12-04T12:51:36 -11:00"),
+			Email:                "jodyholcomb@flotonic.com",
+			Phone:                "9255-943-719",
+			Signature:            "Don't Worry Be Happy!",
+			OrganizationID:       118,
+			Tags:                 []string{"Hanover", "Woodlake", "Saticoy", "Hinsdale"},
+			Suspended:            true,
+			Role:                 "end-user",
+			SubmittedTicketCount: 2,
+			AssignedTicketCount:  2,
+		},
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("674a19a1-c330-45fb-8b61-b4d77ba87130")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/674a19a1-c330-45fb-8b61-b4d77ba87130.json",
+				ExternalID:     uuid.Must(uuid.Parse("050ea8ce-251c-44c8-b71c-535dd9072a74")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-07T08:24:53 -11:00"),
+				Type:           "task",
+				Subject:        "A Drama in St. Pierre and Miquelon",
+				Description:    "Incididunt exercitation voluptate eu laborum proident Lorem minim pariatur. Lorem culpa amet Lorem Lorem commodo anim deserunt do consectetur sunt.",
+				Priority:       "low",
+				Status:         "open",
+				SubmitterID:    49,
+				AssigneeID:     14,
+				OrganizationID: 109,
+				Tags:           []string{"Connecticut", "Arkansas", "Missouri", "Alabama"},
+				DueAt:          models.MustParseTimestamp("2016-08-15T06:13:11 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("55135930-9f1f-43df-a9fd-2105fff74578")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/55135930-9f1f-43df-a9fd-2105fff74578.json",
-			ExternalID:     uuid.Must(uuid.Parse("f0dc9986-6552-4a84-84ba-e9c67453a55a")),
-			CreatedAt:      "2016-03-24T08:06:32 -11:00",
-			Type:           "problem",
-			Subject:        "A Problem in Mexico",
-			Description:    "Exercitation in pariatur ex est dolore duis et do excepteur ullamco commodo reprehenderit. Exercitation aute excepteur dolore laboris consequat ullamco irure id ipsum cillum esse.",
-			Priority:       "normal",
-			Status:         "open",
-			SubmitterID:    74,
-			AssigneeID:     49,
-			OrganizationID: 116,
-			Tags:           []string{"Utah", "Hawaii", "Alaska", "Maryland"},
-			DueAt:          "2016-08-14T07:14:25 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "submitted_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("6e77bbf1-5fc7-4f41-aeb1-74f8730f974b")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/6e77bbf1-5fc7-4f41-aeb1-74f8730f974b.json",
+				ExternalID:     uuid.Must(uuid.Parse("3ce8b7f5-952b-485a-a6c3-8d5259d3850a")),
+				CreatedAt:      models.MustParseTimestamp("2016-06-24T07:57:38 -10:00"),
+				Type:           "problem",
+				Subject:        "A Problem in Guatemala",
+				Description:    "Ex labore dolor commodo magna ex pariatur sunt amet ad quis duis laborum. Fugiat anim non esse eu sunt elit.",
+				Priority:       "high",
+				Status:         "open",
+				SubmitterID:    49,
+				AssigneeID:     26,
+				OrganizationID: 119,
+				Tags:           []string{"Texas", "Nevada", "Oregon", "Arizona"},
+				HasIncidents:   true,
+				DueAt:          models.MustParseTimestamp("2016-08-01T11:20:58 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("674a19a1-c330-45fb-8b61-b4d77ba87130")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/674a19a1-c330-45fb-8b61-b4d77ba87130.json",
-			ExternalID:     uuid.Must(uuid.Parse("050ea8ce-251c-44c8-b71c-535dd9072a74")),
-			CreatedAt:      "2016-03-07T08:24:53 -11:00",
-			Type:           "task",
-			Subject:        "A Drama in St. Pierre and Miquelon",
-			Description:    "Incididunt exercitation voluptate eu laborum proident Lorem minim pariatur. Lorem culpa amet Lorem Lorem commodo anim deserunt do consectetur sunt.",
-			Priority:       "low",
-			Status:         "open",
-			SubmitterID:    49,
-			AssigneeID:     14,
-			OrganizationID: 109,
-			Tags:           []string{"Connecticut", "Arkansas", "Missouri", "Alabama"},
-			DueAt:          "2016-08-15T06:13:11 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("e33110bb-fd7b-4983-987a-4172a9e24919")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/e33110bb-fd7b-4983-987a-4172a9e24919.json",
+				ExternalID:     uuid.Must(uuid.Parse("8b12ee7a-9b59-4b84-af4e-6f2682bcdddf")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-14T01:50:07 -11:00"),
+				Type:           "problem",
+				Subject:        "A Catastrophe in US Minor Outlying Islands",
+				Description:    "Nisi Lorem labore cillum laborum dolor voluptate incididunt. Cupidatat aliqua in mollit adipisicing ullamco qui do fugiat.",
+				Priority:       "high",
+				Status:         "closed",
+				SubmitterID:    29,
+				AssigneeID:     49,
+				OrganizationID: 122,
+				Tags:           []string{"Georgia", "Tennessee", "Mississippi", "Marshall Islands"},
+				DueAt:          models.MustParseTimestamp("2016-08-05T07:03:16 -10:00"),
+				Via:            "web",
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("6e77bbf1-5fc7-4f41-aeb1-74f8730f974b")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/6e77bbf1-5fc7-4f41-aeb1-74f8730f974b.json",
-			ExternalID:     uuid.Must(uuid.Parse("3ce8b7f5-952b-485a-a6c3-8d5259d3850a")),
-			CreatedAt:      "2016-06-24T07:57:38 -10:00",
-			Type:           "problem",
-			Subject:        "A Problem in Guatemala",
-			Description:    "Ex labore dolor commodo magna ex pariatur sunt amet ad quis duis laborum. Fugiat anim non esse eu sunt elit.",
-			Priority:       "high",
-			Status:         "open",
-			SubmitterID:    49,
-			AssigneeID:     26,
-			OrganizationID: 119,
-			Tags:           []string{"Texas", "Nevada", "Oregon", "Arizona"},
-			HasIncidents:   true,
-			DueAt:          "2016-08-01T11:20:58 -10:00",
-			Via:            "voice",
+		&models.RelatedModel{
+			Relation: "assigned_tickets",
+			Model: &models.Ticket{
+				ID:             uuid.Must(uuid.Parse("55135930-9f1f-43df-a9fd-2105fff74578")),
+				URL:            "http://initech.zendesk.com/api/v2/tickets/55135930-9f1f-43df-a9fd-2105fff74578.json",
+				ExternalID:     uuid.Must(uuid.Parse("f0dc9986-6552-4a84-84ba-e9c67453a55a")),
+				CreatedAt:      models.MustParseTimestamp("2016-03-24T08:06:32 -11:00"),
+				Type:           "problem",
+				Subject:        "A Problem in Mexico",
+				Description:    "Exercitation in pariatur ex est dolore duis et do excepteur ullamco commodo reprehenderit. Exercitation aute excepteur dolore laboris consequat ullamco irure id ipsum cillum esse.",
+				Priority:       "normal",
+				Status:         "open",
+				SubmitterID:    74,
+				AssigneeID:     49,
+				OrganizationID: 116,
+				Tags:           []string{"Utah", "Hawaii", "Alaska", "Maryland"},
+				DueAt:          models.MustParseTimestamp("2016-08-14T07:14:25 -10:00"),
+				Via:            "voice",
+				Overdue:        true,
+			},
 		},
-		&models.Ticket{
-			ID:             uuid.Must(uuid.Parse("e33110bb-fd7b-4983-987a-4172a9e24919")),
-			URL:            "http://initech.zendesk.com/api/v2/tickets/e33110bb-fd7b-4983-987a-4172a9e24919.json",
-			ExternalID:     uuid.Must(uuid.Parse("8b12ee7a-9b59-4b84-af4e-6f2682bcdddf")),
-			CreatedAt:      "2016-03-14T01:50:07 -11:00",
-			Type:           "problem",
-			Subject:        "A Catastrophe in US Minor Outlying Islands",
-			Description:    "Nisi Lorem labore cillum laborum dolor voluptate incididunt. Cupidatat aliqua in mollit adipisicing ullamco qui do fugiat.",
-			Priority:       "high",
-			Status:         "closed",
-			SubmitterID:    29,
-			AssigneeID:     49,
-			OrganizationID: 122,
-			Tags:           []string{"Georgia", "Tennessee", "Mississippi", "Marshall Islands"},
-			DueAt:          "2016-08-05T07:03:16 -10:00",
-			Via:            "web",
+		&models.RelatedModel{
+			Relation: "organization",
+			Model: &models.Organization{
+				ID:          118,
+				URL:         "http://initech.zendesk.com/api/v2/organizations/118.json",
+				ExternalID:  uuid.Must(uuid.Parse("6970300e-f211-4c01-a538-70b4464a1d84")),
+				Name:        "Limozen",
+				DomainNames: []string{"otherway.com", "rodeomad.com", "suremax.com", "fishland.com"},
+				CreatedAt:   models.MustParseTimestamp("2016-02-11T04:24:09 -11:00"),
+				Details:     "MegaCorp",
+				Tags:        []string{"Leon", "Ferguson", "Olsen", "Walsh"},
+				UserCount:   2,
+				TicketCount: 11,
+			},
 		},
 	}
 )
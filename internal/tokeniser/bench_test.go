@@ -0,0 +1,35 @@
+package tokeniser_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/satrap-illustrations/zs/internal/models"
+	"github.com/satrap-illustrations/zs/internal/tokeniser"
+)
+
+func BenchmarkTokenise(b *testing.B) {
+	ticket := &models.Ticket{
+		ID:             uuid.Must(uuid.Parse("0ebe753c-9c78-458a-817f-3993780bedbf")),
+		URL:            "http://initech.zendesk.com/api/v2/tickets/0ebe753c-9c78-458a-817f-3993780bedbf.json",
+		ExternalID:     uuid.Must(uuid.Parse("537ad752-9056-42c9-86db-f0bdf06d3c10")),
+		CreatedAt:      models.MustParseTimestamp("2016-05-19T12:19:56 -10:00"),
+		Type:           "problem",
+		Subject:        "A Nuisance in Seychelles",
+		Description:    "Consequat enim velit magna ad sit. Lorem mollit proident est id aliqua ea ea est aliquip magna.",
+		Priority:       "high",
+		Status:         "pending",
+		SubmitterID:    23,
+		AssigneeID:     56,
+		OrganizationID: 118,
+		Tags:           []string{"Missouri", "Alabama", "Virginia", "Virgin Islands"},
+		HasIncidents:   true,
+		DueAt:          models.MustParseTimestamp("2016-08-18T03:33:30 -10:00"),
+		Via:            "chat",
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = tokeniser.Tokenise(ticket)
+	}
+}
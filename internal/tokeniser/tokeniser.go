@@ -3,6 +3,7 @@ package tokeniser
 import (
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/satrap-illustrations/zs/internal/models"
@@ -12,63 +13,163 @@ type Token struct {
 	Text, Field string
 }
 
-// Tokenise extracts tokens from a model
-//
-//nolint:revive
+// Tokenise extracts tokens from a model, including its extra fields by their dotted path.
 func Tokenise(m models.Model) []Token {
+	return appendExtraTokens(appendFieldTokens([]Token{}, m, func(string) bool { return true }), m)
+}
+
+// TokeniseStored extracts the tokens of the fields of a model that are read from the data, leaving out its computed fields.
+func TokeniseStored(m models.Model) []Token {
+	return appendExtraTokens(appendFieldTokens([]Token{}, m, func(field string) bool {
+		return !models.IsComputed(m, field)
+	}), m)
+}
+
+// TokeniseComputed extracts the tokens of the computed fields of a model.
+func TokeniseComputed(m models.Model) []Token {
+	return appendFieldTokens([]Token{}, m, func(field string) bool {
+		return models.IsComputed(m, field)
+	})
+}
+
+// TokeniseField extracts the tokens of one field of a model, declared, computed, or extra by its dotted path.
+func TokeniseField(m models.Model, field string) []Token {
+	if value, err := m.ValueAt(field); err == nil {
+		return appendTokens([]Token{}, field, value)
+	}
 	tokens := []Token{}
+	for _, value := range m.ExtraFields().Flatten()[field] {
+		tokens = appendTokens(tokens, field, value)
+	}
+	return tokens
+}
+
+// TokeniseQuery extracts the tokens of a query of a field, the words of it as they are extracted from a string value.
+func TokeniseQuery(field, query string) []Token {
+	return appendTokens([]Token{}, field, query)
+}
+
+// TokeniseAll tokenises docs with tokenise on at most workers goroutines, and returns the tokens of each document at its index.
+func TokeniseAll[T models.Model](docs []T, workers int, tokenise func(models.Model) []Token) [][]Token {
+	out := make([][]Token, len(docs))
+	workers = max(1, min(workers, len(docs)))
+	chunk := (len(docs) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(docs); start += chunk {
+		end := min(start+chunk, len(docs))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				out[i] = tokenise(docs[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return out
+}
+
+// appendFieldTokens appends the tokens of the declared fields of a model that keep returns true for.
+func appendFieldTokens(tokens []Token, m models.Model, keep func(field string) bool) []Token {
 	fields := m.Fields()
 	for el := fields.Front(); el != nil; el = el.Next() {
-		// When the data has other types, this needs to be extended
-		switch value := m.ValueAtIdx(el.Value).(type) {
-		case string:
-			// allow searching for empty strings
-			if value == "" {
-				tokens = append(tokens, Token{Text: "", Field: el.Key})
+		if keep(el.Key) {
+			tokens = appendTokens(tokens, el.Key, m.ValueAtIdx(el.Value))
+		}
+	}
+	return tokens
+}
+
+// appendExtraTokens appends the tokens of the extra fields of a model by their dotted path,
+// except those models.Shadowed by a computed field, which is searched by the name instead.
+func appendExtraTokens(tokens []Token, m models.Model) []Token {
+	for path, values := range m.ExtraFields().Flatten() {
+		if models.Shadowed(m, path) {
+			continue
+		}
+		for _, value := range values {
+			tokens = appendTokens(tokens, path, value)
+		}
+	}
+	return tokens
+}
+
+// appendTokens appends the tokens extracted from the value of a field.
+//
+//nolint:revive
+func appendTokens(tokens []Token, field string, val any) []Token {
+	// When the data has other types, this needs to be extended
+	switch value := val.(type) {
+	case string:
+		// allow searching for empty strings
+		if value == "" {
+			return append(tokens, Token{Text: "", Field: field})
+		}
+
+		for _, s := range strings.Split(value, " ") {
+			if s == "" {
 				continue
 			}
-
-			for _, s := range strings.Split(value, " ") {
+			tokens = append(tokens, Token{
+				Text:  normalise(s),
+				Field: field,
+			})
+		}
+	case []string:
+		for _, t := range value {
+			for _, s := range strings.Split(t, " ") {
 				if s == "" {
 					continue
 				}
 				tokens = append(tokens, Token{
 					Text:  normalise(s),
-					Field: el.Key,
+					Field: field,
 				})
 			}
-		case []string:
-			for _, t := range value {
-				for _, s := range strings.Split(t, " ") {
-					if s == "" {
-						continue
-					}
-					tokens = append(tokens, Token{
-						Text:  normalise(s),
-						Field: el.Key,
-					})
-				}
-			}
-		case int:
-			tokens = append(tokens, Token{
-				Text:  strconv.Itoa(value),
-				Field: el.Key,
-			})
-		case bool:
-			tokens = append(tokens, Token{
-				Text:  strconv.FormatBool(value),
-				Field: el.Key,
-			})
-		case uuid.UUID:
-			// Skip the zero value. Even random UUID have some non-zero bits.
-			if value == uuid.UUID([16]byte{}) {
-				continue
-			}
+		}
+	case int:
+		tokens = append(tokens, Token{
+			Text:  strconv.Itoa(value),
+			Field: field,
+		})
+	case float64:
+		// numbers in extra fields are decoded as float64
+		tokens = append(tokens, Token{
+			Text:  strconv.FormatFloat(value, 'f', -1, 64),
+			Field: field,
+		})
+	case bool:
+		tokens = append(tokens, Token{
+			Text:  strconv.FormatBool(value),
+			Field: field,
+		})
+	case nil:
+		// null in extra fields is searchable like an empty string
+		tokens = append(tokens, Token{Text: "", Field: field})
+	case models.Enum:
+		// Enumerated values are matched whole, the empty value for a missing field.
+		tokens = append(tokens, Token{
+			Text:  value.String(),
+			Field: field,
+		})
+	case models.Timestamp:
+		// The empty term keeps missing timestamps searchable, like empty strings.
+		for _, term := range value.Terms() {
 			tokens = append(tokens, Token{
-				Text:  value.String(),
-				Field: el.Key,
+				Text:  term,
+				Field: field,
 			})
 		}
+	case uuid.UUID:
+		// Skip the zero value. Even random UUID have some non-zero bits.
+		if value == uuid.UUID([16]byte{}) {
+			return tokens
+		}
+		tokens = append(tokens, Token{
+			Text:  value.String(),
+			Field: field,
+		})
 	}
 	return tokens
 }
@@ -2,6 +2,7 @@ package tokeniser_test
 
 import (
 	"cmp"
+	"fmt"
 	"slices"
 	"testing"
 
@@ -28,6 +29,8 @@ func TestTokenise(t *testing.T) {
 				{Field: "name"},
 				{Field: "url"},
 				{Text: "0", Field: "_id"},
+				{Text: "0", Field: "ticket_count"},
+				{Text: "0", Field: "user_count"},
 				{Text: "false", Field: "shared_tickets"},
 			},
 		},
@@ -39,14 +42,18 @@ func TestTokenise(t *testing.T) {
 				ExternalID:  uuid.Must(uuid.Parse("6970300e-f211-4c01-a538-70b4464a1d84")),
 				Name:        "Limozen",
 				DomainNames: []string{"otherway.com", "rodeomad.com", "suremax.com", "fishland.com"},
-				CreatedAt:   "2016-02-11T04:24:09 -11:00",
+				CreatedAt:   models.MustParseTimestamp("2016-02-11T04:24:09 -11:00"),
 				Details:     "MegaCorp",
 				Tags:        []string{"Leon", "Ferguson", "Olsen", "Walsh"},
 			},
 			expectedTokens: []tokeniser.Token{
-				{Text: "-11:00", Field: "created_at"},
+				{Text: "0", Field: "ticket_count"},
+				{Text: "0", Field: "user_count"},
 				{Text: "118", Field: "_id"},
-				{Text: "2016-02-11T04:24:09", Field: "created_at"},
+				{Text: "2016-02-11T04:24:09 -11:00", Field: "created_at"},
+				{Text: "2016-02-11", Field: "created_at"},
+				{Text: "2016-02", Field: "created_at"},
+				{Text: "2016", Field: "created_at"},
 				{Text: "6970300e-f211-4c01-a538-70b4464a1d84", Field: "external_id"},
 				{Text: "Ferguson", Field: "tags"},
 				{Text: "Leon", Field: "tags"},
@@ -69,7 +76,7 @@ func TestTokenise(t *testing.T) {
 				ID:             uuid.Must(uuid.Parse("0ebe753c-9c78-458a-817f-3993780bedbf")),
 				URL:            "http://initech.zendesk.com/api/v2/tickets/0ebe753c-9c78-458a-817f-3993780bedbf.json",
 				ExternalID:     uuid.Must(uuid.Parse("537ad752-9056-42c9-86db-f0bdf06d3c10")),
-				CreatedAt:      "2016-05-19T12:19:56 -10:00",
+				CreatedAt:      models.MustParseTimestamp("2016-05-19T12:19:56 -10:00"),
 				Type:           "problem",
 				Subject:        "A Nuisance in Seychelles",
 				Description:    "Consequat enim velit magna ad sit. Lorem mollit proident est id aliqua ea ea est aliquip magna.",
@@ -80,16 +87,21 @@ func TestTokenise(t *testing.T) {
 				OrganizationID: 118,
 				Tags:           []string{"Missouri", "Alabama", "Virginia", "Virgin Islands"},
 				HasIncidents:   true,
-				DueAt:          "2016-08-18T03:33:30 -10:00",
+				DueAt:          models.MustParseTimestamp("2016-08-18T03:33:30 -10:00"),
 				Via:            "chat",
 			},
 			expectedTokens: []tokeniser.Token{
-				{Text: "-10:00", Field: "created_at"},
-				{Text: "-10:00", Field: "due_at"},
+				{Text: "0", Field: "group_id"},
 				{Text: "0ebe753c-9c78-458a-817f-3993780bedbf", Field: "_id"},
 				{Text: "118", Field: "organization_id"},
-				{Text: "2016-05-19T12:19:56", Field: "created_at"},
-				{Text: "2016-08-18T03:33:30", Field: "due_at"},
+				{Text: "2016-05-19T12:19:56 -10:00", Field: "created_at"},
+				{Text: "2016-05-19", Field: "created_at"},
+				{Text: "2016-05", Field: "created_at"},
+				{Text: "2016", Field: "created_at"},
+				{Text: "2016-08-18T03:33:30 -10:00", Field: "due_at"},
+				{Text: "2016-08-18", Field: "due_at"},
+				{Text: "2016-08", Field: "due_at"},
+				{Text: "2016", Field: "due_at"},
 				{Text: "23", Field: "submitter_id"},
 				{Text: "537ad752-9056-42c9-86db-f0bdf06d3c10", Field: "external_id"},
 				{Text: "56", Field: "assignee_id"},
@@ -112,6 +124,7 @@ func TestTokenise(t *testing.T) {
 				{Text: "enim", Field: "description"},
 				{Text: "est", Field: "description"},
 				{Text: "est", Field: "description"},
+				{Text: "false", Field: "overdue"},
 				{Text: "high", Field: "priority"},
 				{
 					Text:  "http://initech.zendesk.com/api/v2/tickets/0ebe753c-9c78-458a-817f-3993780bedbf.json",
@@ -138,10 +151,10 @@ func TestTokenise(t *testing.T) {
 				ExternalID:     uuid.Must(uuid.Parse("4acd4eb0-9168-4270-b09f-09600a05b0b2")),
 				Name:           "Key Mendez",
 				Alias:          "Mr Lucile",
-				CreatedAt:      "2016-04-23T12:00:11 -10:00",
+				CreatedAt:      models.MustParseTimestamp("2016-04-23T12:00:11 -10:00"),
 				Locale:         "zh-CN",
 				Timezone:       "Nigeria",
-				LastLoginAt:    "2014-06-03T02:26:28 -10:00",
+				LastLoginAt:    models.MustParseTimestamp("2014-06-03T02:26:28 -10:00"),
 				Email:          "lucilemendez@flotonic.com",
 				Phone:          "8774-883-991",
 				Signature:      "Don't Worry Be Happy!",
@@ -150,11 +163,17 @@ func TestTokenise(t *testing.T) {
 				Role:           "agent",
 			},
 			expectedTokens: []tokeniser.Token{
-				{Text: "-10:00", Field: "created_at"},
-				{Text: "-10:00", Field: "last_login_at"},
+				{Text: "0", Field: "assigned_ticket_count"},
+				{Text: "0", Field: "submitted_ticket_count"},
 				{Text: "118", Field: "organization_id"},
-				{Text: "2014-06-03T02:26:28", Field: "last_login_at"},
-				{Text: "2016-04-23T12:00:11", Field: "created_at"},
+				{Text: "2014-06-03T02:26:28 -10:00", Field: "last_login_at"},
+				{Text: "2014-06-03", Field: "last_login_at"},
+				{Text: "2014-06", Field: "last_login_at"},
+				{Text: "2014", Field: "last_login_at"},
+				{Text: "2016-04-23T12:00:11 -10:00", Field: "created_at"},
+				{Text: "2016-04-23", Field: "created_at"},
+				{Text: "2016-04", Field: "created_at"},
+				{Text: "2016", Field: "created_at"},
 				{Text: "4acd4eb0-9168-4270-b09f-09600a05b0b2", Field: "external_id"},
 				{Text: "59", Field: "_id"},
 				{Text: "8774-883-991", Field: "phone"},
@@ -204,3 +223,88 @@ func TestTokenise(t *testing.T) {
 		})
 	}
 }
+
+func TestTokeniseExtras(t *testing.T) {
+	t.Parallel()
+
+	tokens := tokeniser.Tokenise(&models.Organization{
+		ID: 1,
+		Extras: models.Extras{
+			"custom_fields": map[string]any{"region": "North EU", "score": 4.5, "vip": true},
+			"fields":        []any{map[string]any{"value": nil}},
+		},
+	})
+
+	for _, expected := range []tokeniser.Token{
+		{Text: "North", Field: "custom_fields.region"},
+		{Text: "EU", Field: "custom_fields.region"},
+		{Text: "4.5", Field: "custom_fields.score"},
+		{Text: "true", Field: "custom_fields.vip"},
+		{Text: "", Field: "fields.value"},
+	} {
+		assert.Assert(t, slices.Contains(tokens, expected), "missing %v", expected)
+	}
+
+	// a field in the data with the name of a computed field is not searched, as the computed field is
+	tokens = tokeniser.Tokenise(&models.Ticket{Extras: models.Extras{"overdue": "yes"}})
+	assert.Assert(t, slices.Contains(tokens, tokeniser.Token{Text: "false", Field: "overdue"}))
+	assert.Assert(t, !slices.Contains(tokens, tokeniser.Token{Text: "yes", Field: "overdue"}))
+}
+
+func TestTokeniseAll(t *testing.T) {
+	t.Parallel()
+
+	docs := []*models.User{}
+	for i := 0; i < 10; i++ {
+		docs = append(docs, &models.User{ID: i, Name: fmt.Sprintf("User %d", i)})
+	}
+
+	for _, workers := range []int{0, 1, 3, 10, 100} {
+		tokens := tokeniser.TokeniseAll(docs, workers, tokeniser.Tokenise)
+		assert.Equal(t, len(docs), len(tokens))
+		for i, doc := range docs {
+			assert.DeepEqual(t, tokeniser.Tokenise(doc), tokens[i])
+		}
+	}
+
+	assert.Equal(t, 0, len(tokeniser.TokeniseAll([]*models.User{}, 4, tokeniser.Tokenise)))
+}
+
+func TestTokeniseStoredAndComputed(t *testing.T) {
+	t.Parallel()
+
+	for _, doc := range []models.Model{
+		&models.Organization{ID: 101, Name: "Enthaze", UserCount: 4, TicketCount: 11},
+		&models.Ticket{ID: uuid.Must(uuid.Parse("0ebe753c-9c78-458a-817f-3993780bedbf")), Subject: "A Problem", Overdue: true},
+		&models.User{ID: 1, Name: "Francisca Rasmussen", SubmittedTicketCount: 2},
+		&models.Group{ID: 1, Name: "Support"},
+	} {
+		stored, computed := tokeniser.TokeniseStored(doc), tokeniser.TokeniseComputed(doc)
+		for _, token := range stored {
+			assert.Assert(t, !models.IsComputed(doc, token.Field), token.Field)
+		}
+		for _, token := range computed {
+			assert.Assert(t, models.IsComputed(doc, token.Field), token.Field)
+		}
+		assert.DeepEqual(t, tokeniser.Tokenise(doc), append(stored, computed...))
+	}
+}
+
+func TestTokeniseField(t *testing.T) {
+	t.Parallel()
+
+	ticket := &models.Ticket{
+		Subject: "A Problem",
+		Overdue: true,
+		Extras:  models.Extras{"custom_fields": map[string]any{"region": "apac"}},
+	}
+	for _, field := range []string{"subject", "overdue", "custom_fields.region", "missing"} {
+		expected := []tokeniser.Token{}
+		for _, token := range tokeniser.Tokenise(ticket) {
+			if token.Field == field {
+				expected = append(expected, token)
+			}
+		}
+		assert.DeepEqual(t, expected, tokeniser.TokeniseField(ticket, field))
+	}
+}
@@ -1,9 +1,15 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -11,12 +17,42 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/satrap-illustrations/zs/internal/models"
 	"github.com/satrap-illustrations/zs/internal/stores"
+	"github.com/satrap-illustrations/zs/internal/stores/cache"
 	"github.com/satrap-illustrations/zs/internal/stores/implementations"
+	"github.com/satrap-illustrations/zs/internal/stores/multi"
+	"github.com/satrap-illustrations/zs/internal/stores/reload"
 	"github.com/satrap-illustrations/zs/internal/tui/selectfromlist"
 )
 
 var ErrNoResults = errors.New("no documents found")
 
+// searchTimeout is how long a search may take before it is cancelled, so the tui never hangs on a slow search.
+const searchTimeout = 10 * time.Second
+
+// pageSize is the number of documents that match a search shown at a time, the next are loaded on scrolling to the end.
+const pageSize = 20
+
+// facetsWidth is the width of the sidebar of the facets of the results of a search, with its border.
+const facetsWidth = 32
+
+// maxBuckets is the most buckets of a facet shown in the sidebar.
+const maxBuckets = 8
+
+// fetchPage fetches the page of results after cursor, the first page for the empty cursor, in the order of sort.
+type fetchPage func(ctx context.Context, cursor string, sort stores.Sort) (stores.Page, error)
+
+// fetchKind is what a page of results is fetched for.
+type fetchKind int
+
+const (
+	// firstPage is the first page of a new search.
+	firstPage fetchKind = iota
+	// sortedPage is the first page again, in a new sort.
+	sortedPage
+	// nextPage is the page after the ones shown.
+	nextPage
+)
+
 type state int
 
 const (
@@ -26,12 +62,19 @@ const (
 	search
 	chosenDocType
 	chosenDocTypeField
+	chosenDocTypeEnumField
 	results
 	listFields
+	exploreDocType
+	exploreID
+	explorePath
 )
 
+// showRelated is the item in the list of relations that shows the documents reached so far.
+const showRelated = "(show related documents)"
+
 type styles struct {
-	docType, field, query, fieldsList, results lipgloss.Style
+	docType, field, query, fieldsList, results, facets lipgloss.Style
 }
 
 func DefaultStyles() *styles {
@@ -64,48 +107,227 @@ func DefaultStyles() *styles {
 			Padding(0, 1).
 			BorderForeground(lipgloss.Color("#ed095d")).
 			BorderStyle(lipgloss.RoundedBorder()),
+		facets: lipgloss.
+			NewStyle().
+			Padding(0, 1).
+			BorderForeground(lipgloss.Color("#154733")).
+			BorderStyle(lipgloss.RoundedBorder()).
+			Width(facetsWidth - 2),
 	}
 }
 
 type (
 	loadStoreMsg       struct{}
-	storeLoadedSuccMsg struct{ store stores.Store }
-	storeLoadErrMsg    struct{ err error }
+	storeLoadedSuccMsg struct {
+		store    stores.Store
+		warnings []error
+		// reloads are the outcomes of reloading the store when the data changes, nil if it is not watched.
+		reloads <-chan reload.Event
+	}
+	storeReloadedMsg struct {
+		event reload.Event
+		// next waits for the next reload.
+		next tea.Cmd
+	}
+	storeLoadErrMsg      struct{ err error }
+	storeLoadProgressMsg struct {
+		progress implementations.Progress
+		// next waits for the next message from the loading store.
+		next tea.Cmd
+	}
+	// pageFetchedMsg is the page of results fetched in the background by the fetch numbered id.
+	pageFetchedMsg struct {
+		id         int
+		kind       fetchKind
+		fetch      fetchPage
+		sortFields []string
+		page       stores.Page
+		err        error
+	}
 )
 
-func loadStore(dataDir string) tea.Cmd {
-	store, err := implementations.NewInvertedStore(dataDir)
-	if err != nil {
-		return func() tea.Msg { return storeLoadErrMsg{err: err} }
+// loadStore loads the store in the background, and returns a command that waits for its progress, then the store.
+// The store is reloaded when the files in its data directories change, and remembers the results of searches until then.
+// Several data directories are loaded in parallel, and searched together.
+func loadStore(dataDirs []string, opts []implementations.Option) tea.Cmd {
+	progress := make(chan implementations.Progress, 1)
+	loaded := make(chan tea.Msg, 1)
+	names := multi.Names(dataDirs)
+	go func() {
+		var mu sync.Mutex
+		warnings := []error{}
+		reloads := map[string]<-chan reload.Event{}
+		warn := func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			warnings = append(warnings, err)
+		}
+
+		sources, err := multi.Load(dataDirs, func(dir string) (stores.Store, error) {
+			name := names[slices.Index(dataDirs, dir)]
+			load := func(opts ...implementations.Option) (stores.Store, error) {
+				return implementations.NewInvertedStore(dir, opts...)
+			}
+			store, err := load(append(slices.Clip(opts),
+				implementations.WithWarnings(warn),
+				implementations.WithProgress(func(p implementations.Progress) {
+					if len(dataDirs) > 1 {
+						p.File = filepath.Join(name, p.File)
+					}
+					// progress that arrives before the last has been shown is dropped, it is soon out of date.
+					select {
+					case progress <- p:
+					default:
+					}
+				}),
+			)...)
+			if err != nil {
+				return nil, err
+			}
+
+			watched, err := reload.Watch(dir, store, func() (stores.Store, error) { return load(opts...) })
+			if err != nil {
+				warn(fmt.Errorf("the data in %s will not be reloaded when it changes: %w", dir, err))
+				return store, nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			reloads[name] = watched.Events()
+			return watched, nil
+		})
+		if err != nil {
+			loaded <- storeLoadErrMsg{err: err}
+			return
+		}
+
+		store := sources[0].Store
+		if len(sources) > 1 {
+			if store, err = multi.New(sources...); err != nil {
+				loaded <- storeLoadErrMsg{err: err}
+				return
+			}
+		}
+		loaded <- storeLoadedSuccMsg{store: cache.New(store), warnings: warnings, reloads: mergeReloads(reloads)}
+	}()
+	return waitForStore(progress, loaded)
+}
+
+// mergeReloads returns the outcomes of reloading each store, by name, as they happen, or nil if none is watched.
+// The errors are prefixed with the name of the store when there are several.
+func mergeReloads(reloads map[string]<-chan reload.Event) <-chan reload.Event {
+	switch len(reloads) {
+	case 0:
+		return nil
+	case 1:
+		for _, events := range reloads {
+			return events
+		}
+	}
+
+	out := make(chan reload.Event)
+	var wg sync.WaitGroup
+	for name, events := range reloads {
+		wg.Add(1)
+		go func(name string, events <-chan reload.Event) {
+			defer wg.Done()
+			for event := range events {
+				if event.Err != nil {
+					event.Err = fmt.Errorf("%s: %w", name, event.Err)
+				}
+				out <- event
+			}
+		}(name, events)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// waitForReload waits for the next outcome of reloading the store.
+func waitForReload(reloads <-chan reload.Event) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-reloads
+		if !ok {
+			return nil
+		}
+		return storeReloadedMsg{event: event, next: waitForReload(reloads)}
+	}
+}
+
+func waitForStore(progress <-chan implementations.Progress, loaded <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-loaded:
+			return msg
+		case p := <-progress:
+			return storeLoadProgressMsg{progress: p, next: waitForStore(progress, loaded)}
+		}
 	}
-	return func() tea.Msg { return storeLoadedSuccMsg{store: store} }
 }
 
 type model struct {
 	state          state
-	dataDir        string
+	dataDirs       []string
+	location       *time.Location
+	storeOpts      []implementations.Option
+	warnings       []error
+	notice         string
+	loading        map[string]implementations.Progress
 	store          stores.Store
 	styles         *styles
 	width, height  int
 	docType, field selectfromlist.Model
 	query          textinput.Model
+	values         selectfromlist.Model
 	resultsErr     error
 	veiwport       viewport.Model
 	quitting       bool
+
+	// resultsText is the results shown so far, the pages of which are fetched by fetchMore.
+	// nextCursor fetches the next page, or is empty if they are all shown.
+	// shown of the total documents that match are shown.
+	resultsText  string
+	fetchMore    fetchPage
+	nextCursor   string
+	shown, total int
+
+	// sortFields are the fields the results can be sorted by, none if they cannot be,
+	// and sortKey is the one they are sorted by, with the empty field for their _id.
+	sortFields []string
+	sortKey    stores.SortKey
+	// facets are shown beside the results of a search.
+	facets []stores.FacetResult
+	// cancelFetch cancels the page of results being fetched, numbered fetchID, and is nil if none is.
+	cancelFetch context.CancelFunc
+	fetchID     int
+
+	// explored is the document whose relations are being explored,
+	// relatedType is a Model of the type reached by following relatedPath from it.
+	explored, relatedType models.Model
+	relatedPath           []string
+	relation              selectfromlist.Model
 }
 
-func InitialModel(dataDir string) model {
+// InitialModel returns the initial state of the tui.
+// Timestamps in results are shown in location, or as written if it is nil.
+// The store is loaded from dataDirs with storeOpts.
+func InitialModel(dataDirs []string, location *time.Location, storeOpts ...implementations.Option) model {
 	styles := DefaultStyles()
 	query := textinput.New()
 	query.ShowSuggestions = true
 
 	return model{
-		dataDir:  dataDir,
-		styles:   styles,
-		docType:  selectfromlist.New("Select a document type...", []string{}),
-		field:    selectfromlist.New("Select a field...", []string{}),
-		query:    query,
-		veiwport: viewport.New(0, 0),
+		dataDirs:  dataDirs,
+		location:  location,
+		storeOpts: storeOpts,
+		loading:   map[string]implementations.Progress{},
+		styles:    styles,
+		docType:   selectfromlist.New("Select a document type...", []string{}),
+		field:     selectfromlist.New("Select a field...", []string{}),
+		query:     query,
+		veiwport:  viewport.New(0, 0),
 	}
 }
 
@@ -118,8 +340,24 @@ func (m model) Clear() (model, tea.Cmd) {
 	m.docType = selectfromlist.New("Select a document type...", m.store.ListDocumentTypes())
 	m.field = selectfromlist.New("Select a field...", []string{})
 	m.query = textinput.New()
+	m.values = selectfromlist.New("Select a value...", []string{})
+	m.explored = nil
+	m.relatedType = nil
+	m.relatedPath = nil
+	m.relation = selectfromlist.New("Select a relation...", []string{})
 	m.veiwport = viewport.New(0, 0)
 	m.resultsErr = nil
+	m.resultsText = ""
+	m.fetchMore = nil
+	m.nextCursor = ""
+	m.shown, m.total = 0, 0
+	m.sortFields = nil
+	m.sortKey = stores.SortKey{}
+	m.facets = nil
+	if m.cancelFetch != nil {
+		m.cancelFetch()
+		m.cancelFetch = nil
+	}
 	return m, cmd
 }
 
@@ -134,7 +372,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.veiwport.Update(msg)
 		// adjust for borders, margins etc
-		m.veiwport.Width = m.width - 4
+		m.veiwport.Width = m.resultsWidth()
 		//nolint:exhaustive
 		switch m.state {
 		case listFields:
@@ -156,7 +394,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case loadStoreMsg:
-		return m, loadStore(m.dataDir)
+		return m, loadStore(m.dataDirs, m.storeOpts)
+
+	case storeLoadProgressMsg:
+		m.loading[msg.progress.File] = msg.progress
+		return m, msg.next
 
 	case storeLoadErrMsg:
 		m.state = storeLoadError
@@ -164,15 +406,48 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case storeLoadedSuccMsg:
 		m.store = msg.store
+		m.warnings = msg.warnings
 		m.docType = selectfromlist.New("Select a document type...", m.store.ListDocumentTypes())
-		return m, nil
+		if msg.reloads == nil {
+			return m, nil
+		}
+		return m, waitForReload(msg.reloads)
+
+	case storeReloadedMsg:
+		if msg.event.Err != nil {
+			m.notice = fmt.Sprintf("Could not reload the data, still searching the data loaded before: %v", msg.event.Err)
+		} else {
+			m.notice = fmt.Sprintf("Data reloaded at %s", time.Now().Format(time.TimeOnly))
+		}
+		return m, msg.next
+
+	case pageFetchedMsg:
+		// the page of a fetch cancelled by a newer one, or by going back to the menu, is dropped.
+		if m.cancelFetch == nil || msg.id != m.fetchID {
+			return m, nil
+		}
+		m.cancelFetch()
+		m.cancelFetch = nil
+		return m.showPage(msg)
 
 	case tea.KeyMsg:
+		// the notice of a reload is shown until the next key is pressed.
+		m.notice = ""
 		switch s := msg.String(); s {
 		case "ctrl+c":
 			m.quitting = true
+			if m.cancelFetch != nil {
+				m.cancelFetch()
+			}
 			return m, tea.Quit
 
+		case "esc":
+			if m.cancelFetch != nil {
+				m.cancelFetch()
+				return m, nil
+			}
+			fallthrough
+
 		default:
 			switch m.state {
 			case storeLoadError:
@@ -180,6 +455,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case header:
 				switch s {
 				case "enter":
+					if m.store == nil {
+						return m, nil
+					}
 					m.state = selectOptions
 					return m, nil
 				default:
@@ -198,6 +476,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.veiwport.Width = m.width - 4
 					m.veiwport.Height = m.height - 4
 					m.veiwport.SetContent(formatFieldsList(m.store.ListFields(), m.veiwport.Width))
+				case "3":
+					m.state = exploreDocType
 				}
 				return m, nil
 			case search:
@@ -222,6 +502,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.state = selectOptions
 					return m.Clear()
 				case "enter":
+					if values := m.store.ListValues(m.docType.SelectedItem(), m.field.SelectedItem()); len(values) > 0 {
+						m.state = chosenDocTypeEnumField
+						m.values = selectfromlist.New(
+							fmt.Sprintf("Select a value of %s...", m.field.SelectedItem()),
+							values,
+						)
+						return m, nil
+					}
 					m.state = chosenDocTypeField
 					m.query.Placeholder = fmt.Sprintf(
 						"Type a value of %s in %s to search for...",
@@ -240,49 +528,116 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.state = selectOptions
 					return m.Clear()
 				case "enter":
-					resultDocs, err := m.store.Search(
-						m.docType.SelectedItem(),
-						m.field.SelectedItem(),
-						m.query.Value(),
-					)
-					if err != nil {
-						m.state = results
-						m.resultsErr = err
-						return m, nil
-					}
-
-					m, cmd = m.Clear()
-					if cmd != nil {
-						return m, cmd
-					}
-					m.veiwport.Width = m.width - 4
-					m.veiwport.Height = m.height - 5
-
-					if len(resultDocs) == 0 {
-						m.state = results
-						m.resultsErr = ErrNoResults
-						return m, nil
-					}
-					formattedResults, err := formatResults(resultDocs, m.veiwport.Width)
+					return m.showResults(m.searchPages(m.query.Value()), m.store.ListFields()[m.docType.SelectedItem()])
+				default:
+					m.query, cmd = m.query.Update(msg)
+					return m, cmd
+				}
+			case chosenDocTypeEnumField:
+				switch s {
+				case "ctrl+d":
+					m.state = selectOptions
+					return m.Clear()
+				case "enter":
+					return m.showResults(m.searchPages(m.values.SelectedItem()), m.store.ListFields()[m.docType.SelectedItem()])
+				default:
+					m.values, cmd = m.values.Update(msg)
+					return m, cmd
+				}
+			case exploreDocType:
+				switch s {
+				case "ctrl+d":
+					m.state = selectOptions
+					return m.Clear()
+				case "enter":
+					m.state = exploreID
+					m.query.Placeholder = fmt.Sprintf("Type the _id of a document in %s...", m.docType.SelectedItem())
+					m.query.Focus()
+					return m, nil
+				default:
+					m.docType, cmd = m.docType.Update(msg)
+					return m, cmd
+				}
+			case exploreID:
+				switch s {
+				case "ctrl+d":
+					m.state = selectOptions
+					return m.Clear()
+				case "enter":
+					doc, err := stores.Find(m.store, m.docType.SelectedItem(), m.query.Value())
 					if err != nil {
 						m.state = results
 						m.resultsErr = err
 						return m, nil
 					}
-					m.veiwport.SetContent(formattedResults)
-					m.state = results
+					m.state = explorePath
+					m.explored = doc
+					m.relatedType = doc
+					m.relation = newRelationList(doc)
 					return m, nil
 				default:
 					m.query, cmd = m.query.Update(msg)
 					return m, cmd
 				}
+			case explorePath:
+				switch s {
+				case "ctrl+d":
+					m.state = selectOptions
+					return m.Clear()
+				case "enter":
+					if selected := m.relation.SelectedItem(); selected != showRelated {
+						relation, _ := models.Relations.Lookup(m.relatedType, selected)
+						m.relatedPath = append(m.relatedPath, selected)
+						m.relatedType = relation.To
+						m.relation = newRelationList(relation.To)
+						return m, nil
+					}
+					return m.showResults(func(ctx context.Context, _ string, _ stores.Sort) (stores.Page, error) {
+						var (
+							related []models.Model
+							err     error
+						)
+						if len(m.relatedPath) > 0 {
+							related, err = m.store.RelatedContext(ctx, m.explored, m.relatedPath...)
+						} else {
+							related, err = m.store.WalkContext(ctx, m.explored, 1)
+						}
+						if err != nil {
+							return stores.Page{}, err
+						}
+						return stores.Page{Hits: []stores.Hit{stores.NewHit(m.explored, nil, related)}, Total: 1}, nil
+					}, nil)
+				default:
+					m.relation, cmd = m.relation.Update(msg)
+					return m, cmd
+				}
 			case results:
 				switch s {
 				case "enter":
 					m.state = selectOptions
 					return m.Clear()
+				case "s":
+					if len(m.sortFields) == 0 || m.resultsErr != nil {
+						return m, nil
+					}
+					// cycle through the fields, and back to the _id after the last one.
+					next := slices.Index(m.sortFields, m.sortKey.Field) + 1
+					m.sortKey = stores.SortKey{Descending: m.sortKey.Descending}
+					if next < len(m.sortFields) {
+						m.sortKey.Field = m.sortFields[next]
+					}
+					return m.sortResults()
+				case "r":
+					if len(m.sortFields) == 0 || m.resultsErr != nil {
+						return m, nil
+					}
+					m.sortKey.Descending = !m.sortKey.Descending
+					return m.sortResults()
 				default:
 					m.veiwport, cmd = m.veiwport.Update(msg)
+					if m.nextCursor != "" && m.veiwport.AtBottom() {
+						return m.showMore()
+					}
 					return m, cmd
 				}
 			case listFields:
@@ -301,6 +656,168 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// searchPages returns the pages of the documents of the selected type whose selected field matches query.
+// The first page has the facets of every document that matches.
+func (m model) searchPages(query string) fetchPage {
+	store, docType, field := m.store, m.docType.SelectedItem(), m.field.SelectedItem()
+	facets := defaultFacets(store, docType)
+	return func(ctx context.Context, cursor string, sort stores.Sort) (stores.Page, error) {
+		page := stores.PageRequest{Limit: pageSize, Cursor: cursor, Sort: sort}
+		if cursor == "" {
+			page.Facets = facets
+		}
+		return store.SearchPage(ctx, docType, field, query, page)
+	}
+}
+
+// defaultFacets are the facets shown beside the results of a search of the document type:
+// the terms of the enumerated fields and of organization_id, the documents created each month, and summaries of the counts.
+func defaultFacets(store stores.Store, documentType string) []stores.Facet {
+	out := []stores.Facet{}
+	for _, field := range store.ListFields()[documentType] {
+		switch {
+		case store.ListValues(documentType, field) != nil, field == "organization_id":
+			out = append(out, stores.Facet{Field: field, Kind: stores.Terms})
+		case field == "created_at":
+			out = append(out, stores.Facet{Field: field, Kind: stores.DateHistogram, Interval: stores.Month})
+		case strings.HasSuffix(field, "_count"):
+			out = append(out, stores.Facet{Field: field, Kind: stores.Stats})
+		}
+	}
+	return out
+}
+
+// resultsWidth is the width of the results, beside the facets if there are any.
+func (m model) resultsWidth() int {
+	if len(m.facets) > 0 {
+		return m.width - 4 - facetsWidth
+	}
+	return m.width - 4
+}
+
+// fetch fetches the page after cursor in the background, and delivers it in a pageFetchedMsg.
+// It cancels the fetch before it, and is cancelled by cancelFetch, or after searchTimeout.
+func (m model) fetch(
+	kind fetchKind,
+	fetch fetchPage,
+	sortFields []string,
+	cursor string,
+	sort stores.Sort,
+) (model, tea.Cmd) {
+	if m.cancelFetch != nil {
+		m.cancelFetch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFetch = cancel
+	m.fetchID++
+	id := m.fetchID
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, searchTimeout)
+		defer cancel()
+		page, err := fetch(ctx, cursor, sort)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			err = fmt.Errorf("the search took longer than %s: %w", searchTimeout, err)
+		case errors.Is(err, context.Canceled):
+			err = fmt.Errorf("the search was cancelled: %w", err)
+		}
+		return pageFetchedMsg{id: id, kind: kind, fetch: fetch, sortFields: sortFields, page: page, err: err}
+	}
+}
+
+// showResults fetches the first page of results, the next are fetched by showMore.
+// They can be sorted by sortFields, or not at all if there are none.
+func (m model) showResults(fetch fetchPage, sortFields []string) (model, tea.Cmd) {
+	return m.fetch(firstPage, fetch, sortFields, "", nil)
+}
+
+// sort returns the sort the results are shown in, nil for the order of their _id.
+func (m model) sort() stores.Sort {
+	key := m.sortKey
+	if key.Field == "" {
+		if !key.Descending {
+			return nil
+		}
+		key.Field = "_id"
+	}
+	return stores.Sort{key}
+}
+
+// sortResults fetches the first page of results again, sorted by sortKey.
+func (m model) sortResults() (model, tea.Cmd) {
+	return m.fetch(sortedPage, m.fetchMore, m.sortFields, "", m.sort())
+}
+
+// showMore fetches the next page of results, unless a page is being fetched already.
+func (m model) showMore() (model, tea.Cmd) {
+	if m.cancelFetch != nil {
+		return m, nil
+	}
+	return m.fetch(nextPage, m.fetchMore, m.sortFields, m.nextCursor, m.sort())
+}
+
+// showPage shows the page of results fetched: the first replaces any shown, the next is shown below them.
+func (m model) showPage(msg pageFetchedMsg) (model, tea.Cmd) {
+	if msg.err != nil {
+		m.state = results
+		m.resultsErr = msg.err
+		return m, nil
+	}
+	page := msg.page
+
+	switch msg.kind {
+	case firstPage:
+		var cmd tea.Cmd
+		m, cmd = m.Clear()
+		if cmd != nil {
+			return m, cmd
+		}
+		m.facets = page.Facets
+		m.veiwport.Width = m.resultsWidth()
+		m.veiwport.Height = m.height - 5
+
+		if page.Total == 0 {
+			m.state = results
+			m.resultsErr = ErrNoResults
+			return m, nil
+		}
+		m.fetchMore = msg.fetch
+		m.sortFields = msg.sortFields
+	case sortedPage:
+		m.resultsText = ""
+		m.shown = 0
+		m.facets = page.Facets
+		m.veiwport.GotoTop()
+	case nextPage:
+	}
+	m.total = page.Total
+	return m.appendPage(page)
+}
+
+func (m model) appendPage(page stores.Page) (model, tea.Cmd) {
+	formattedResults, err := formatResults(page.Hits, m.veiwport.Width, m.location)
+	if err != nil {
+		m.state = results
+		m.resultsErr = err
+		return m, nil
+	}
+	m.shown += len(page.Hits)
+	m.nextCursor = page.NextCursor
+	m.resultsText += formattedResults
+	m.veiwport.SetContent(m.resultsText)
+	m.state = results
+	return m, nil
+}
+
+// newRelationList lists the relations from documents of the same type as m.
+func newRelationList(m models.Model) selectfromlist.Model {
+	items := []string{showRelated}
+	for _, relation := range models.Relations.From(m) {
+		items = append(items, relation.Name)
+	}
+	return selectfromlist.New(fmt.Sprintf("Select a relation from %s...", m.DocumentType()), items)
+}
+
 func (m model) View() string {
 	if m.width == 0 {
 		return "Loading..."
@@ -336,7 +853,8 @@ Welcome to Zendesk Search`
 	const searchText = `
 Select search options:
 1) Search Zendesk
-2) View a list of searchable fields`
+2) View a list of searchable fields
+3) Explore the documents related to a document`
 
 	s := func() string {
 		switch m.state {
@@ -344,9 +862,10 @@ Select search options:
 			return lipgloss.JoinVertical(
 				lipgloss.Left,
 				headerText,
-				fmt.Sprintf("Could not read data from %q", m.dataDir),
+				fmt.Sprintf("Could not read data from %q", strings.Join(m.dataDirs, ", ")),
 				fmt.Sprintf(
-					"Ensure you have the files %q present in this directory.\n",
+					"Ensure you have the files %q, or their .ndjson or .jsonl equivalents, or .csv for users and organizations, "+
+						"gzipped or not, present in this directory.\n",
 					[]string{"organizations.json", "tickets.json", "users.json"},
 				),
 			)
@@ -355,6 +874,8 @@ Select search options:
 				lipgloss.Left,
 				headerText,
 				instructions,
+				formatProgress(m.store == nil, m.loading),
+				formatWarnings(m.warnings),
 			)
 		case selectOptions:
 			return lipgloss.JoinVertical(
@@ -392,6 +913,49 @@ Select search options:
 				),
 				m.styles.query.Render(m.query.View()),
 			)
+		case chosenDocTypeEnumField:
+			return lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerText,
+				instructions,
+				"",
+				fmt.Sprintf(
+					"Searching the %q field in %q documents",
+					m.field.SelectedItem(),
+					m.docType.SelectedItem(),
+				),
+				m.styles.query.Render(m.values.View()),
+			)
+		case exploreDocType:
+			return lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerText,
+				instructions,
+				m.styles.docType.Render(m.docType.View()),
+			)
+		case exploreID:
+			return lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerText,
+				instructions,
+				"",
+				fmt.Sprintf("Exploring a document in %q", m.docType.SelectedItem()),
+				m.styles.query.Render(m.query.View()),
+			)
+		case explorePath:
+			return lipgloss.JoinVertical(
+				lipgloss.Left,
+				headerText,
+				instructions,
+				"",
+				fmt.Sprintf(
+					"Exploring %s %s: %s",
+					m.explored.DocumentType(),
+					m.explored.StringID(),
+					strings.Join(append([]string{m.explored.DocumentType()}, m.relatedPath...), " → "),
+				),
+				m.styles.field.Render(m.relation.View()),
+			)
 		case results:
 			if m.resultsErr != nil {
 				return lipgloss.JoinVertical(
@@ -403,11 +967,34 @@ Select search options:
 					"Press 'enter' to go back to the main menu.",
 				)
 			}
+			found := "Found the following documents:"
+			if m.shown < m.total {
+				found = fmt.Sprintf("Found %d documents, showing the first %d, scroll down for more:", m.total, m.shown)
+			}
+			back := "Press 'enter' to go back to the main menu."
+			if len(m.sortFields) > 0 {
+				sortedBy := "_id"
+				if sort := m.sort(); sort != nil {
+					sortedBy = sort.String()
+				}
+				back = fmt.Sprintf(
+					"Sorted by %s. Press 's' to sort by the next field, 'r' to reverse, 'enter' to go back to the main menu.",
+					sortedBy,
+				)
+			}
+			resultsView := m.styles.results.Render(m.veiwport.View())
+			if len(m.facets) > 0 {
+				resultsView = lipgloss.JoinHorizontal(
+					lipgloss.Top,
+					resultsView,
+					m.styles.facets.Height(m.veiwport.Height).Render(formatFacets(m.facets, facetsWidth-4, m.veiwport.Height)),
+				)
+			}
 			return lipgloss.JoinVertical(
 				lipgloss.Left,
-				"Found the following documents:",
-				"Press 'enter' to go back to the main menu.",
-				m.styles.results.Render(m.veiwport.View()),
+				found,
+				back,
+				resultsView,
 			)
 		case listFields:
 			return lipgloss.JoinVertical(
@@ -420,6 +1007,13 @@ Select search options:
 		}
 	}()
 
+	if m.notice != "" {
+		s = lipgloss.JoinVertical(lipgloss.Left, s, m.notice)
+	}
+	if m.cancelFetch != nil {
+		s = lipgloss.JoinVertical(lipgloss.Left, s, "Searching... Press 'esc' to cancel.")
+	}
+
 	// So that the prompt does no overwrite the last line.
 	// See https://github.com/charmbracelet/bubbletea/issues/304
 	if m.quitting {
@@ -428,22 +1022,110 @@ Select search options:
 	return s
 }
 
-func formatResults(results []models.Model, width int) (string, error) {
+// formatResults shows each hit, underlined with '=' and labelled with the fields it matched,
+// followed by the documents related to it, underlined with '-' and labelled with their relation.
+func formatResults(hits []stores.Hit, width int, location *time.Location) (string, error) {
 	var out strings.Builder
-	for _, result := range results {
-		_, _ = fmt.Fprintf(&out, "%s\n", result.DocumentType())
-		_, _ = fmt.Fprintf(&out, "%s\n", strings.Repeat("-", width))
+	for _, hit := range hits {
+		for _, result := range hit.Models() {
+			header := result.DocumentType()
+			if source := models.SourceOf(result); source != "" {
+				header = fmt.Sprintf("%s [%s]", header, source)
+			}
+			underline := "-"
+			if related, ok := result.(*models.RelatedModel); ok {
+				header = fmt.Sprintf("%s (%s)", header, related.Relation)
+			} else {
+				underline = "="
+				if len(hit.MatchedFields) > 0 {
+					header = fmt.Sprintf("%s, matched %s", header, strings.Join(hit.MatchedFields, ", "))
+				}
+			}
+			_, _ = fmt.Fprintf(&out, "%s\n", header)
+			_, _ = fmt.Fprintf(&out, "%s\n", strings.Repeat(underline, width))
 
-		buf, err := models.StringOf(result)
-		if err != nil {
-			return "", fmt.Errorf("failed to string value: %w", err)
+			buf, err := models.StringOfIn(result, location)
+			if err != nil {
+				return "", fmt.Errorf("failed to string value: %w", err)
+			}
+			_, _ = fmt.Fprintf(&out, "%s\n", buf)
+			_, _ = fmt.Fprintln(&out, "")
 		}
-		_, _ = fmt.Fprintf(&out, "%s\n", buf)
-		_, _ = fmt.Fprintln(&out, "")
 	}
 	return out.String(), nil
 }
 
+// formatFacets shows the most common buckets of each facet, or its summary, in columns of width, in at most height lines.
+func formatFacets(facets []stores.FacetResult, width, height int) string {
+	var out strings.Builder
+	for _, facet := range facets {
+		_, _ = fmt.Fprintf(&out, "%s\n%s\n", facet.Facet.String(), strings.Repeat("-", width))
+		if facet.Kind == stores.Stats {
+			summary := facet.Summary
+			_, _ = fmt.Fprintf(&out, "%-*s%*d\n", width/2, "count", width-width/2, summary.Count)
+			for _, stat := range []struct {
+				name  string
+				value float64
+			}{{"min", summary.Min}, {"max", summary.Max}, {"mean", summary.Mean}} {
+				_, _ = fmt.Fprintf(&out, "%-*s%*.4g\n", width/2, stat.name, width-width/2, stat.value)
+			}
+		}
+		for i, bucket := range facet.Buckets {
+			if i == maxBuckets {
+				_, _ = fmt.Fprintf(&out, "and %d more\n", len(facet.Buckets)-maxBuckets)
+				break
+			}
+			count := strconv.Itoa(bucket.Count)
+			key := bucket.Key
+			if key == "" {
+				key = "(empty)"
+			}
+			if keyWidth := width - len(count) - 1; len(key) > keyWidth {
+				key = key[:max(0, keyWidth-1)] + "…"
+			}
+			_, _ = fmt.Fprintf(&out, "%-*s %s\n", width-len(count)-1, key, count)
+		}
+		_, _ = fmt.Fprintln(&out, "")
+	}
+	lines := strings.Split(out.String(), "\n")
+	return strings.Join(lines[:min(len(lines), height)], "\n")
+}
+
+// formatProgress shows how much of each data file has been read while the store is loading.
+func formatProgress(loadingStore bool, loading map[string]implementations.Progress) string {
+	if !loadingStore {
+		return ""
+	}
+	files := make([]string, 0, len(loading))
+	for file := range loading {
+		files = append(files, file)
+	}
+	slices.Sort(files)
+
+	var out strings.Builder
+	_, _ = fmt.Fprintln(&out, "\nLoading...")
+	for _, file := range files {
+		p := loading[file]
+		percent := 100
+		if p.Size > 0 {
+			percent = int(100 * p.Read / p.Size)
+		}
+		_, _ = fmt.Fprintf(&out, "%-20s %3d%% %d documents\n", file, percent, p.Documents)
+	}
+	return out.String()
+}
+
+func formatWarnings(warnings []error) string {
+	switch len(warnings) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("\nWarning: %v", warnings[0])
+	default:
+		return fmt.Sprintf("\nWarning: %v, and %d more invalid values", warnings[0], len(warnings)-1)
+	}
+}
+
 func formatFieldsList(fieldsMap map[string][]string, width int) string {
 	var out strings.Builder
 	for docType, fields := range fieldsMap {